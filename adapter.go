@@ -0,0 +1,117 @@
+package lit
+
+import (
+	"bytes"
+	"maps"
+	"net/http"
+	"sync"
+)
+
+// FromHTTPMiddleware converts a standard net/http middleware into a [Middleware].
+//
+// The transformed handler runs as part of the middleware chain: changes made by m to the *http.Request passed
+// to the next handler (such as values added to its context) are propagated to the [*Request] of the handler and, if
+// the next handler returns before m, of the middlewares applied before the converted one. The handler receives a
+// copy of the request (see [*Request.Clone]), so it can safely be called in another goroutine by m.
+//
+// Everything m writes, including what the handler writes through the (possibly wrapped) http.ResponseWriter, is
+// captured and responded by the returned [Response]. As the response is buffered, streams are only sent once they
+// are complete and the http.ResponseWriter can't be hijacked.
+//
+// If m is nil, FromHTTPMiddleware panics.
+func FromHTTPMiddleware(m func(http.Handler) http.Handler) Middleware {
+	if m == nil {
+		panic("m should not be nil")
+	}
+
+	return func(h Handler) Handler {
+		return func(r *Request) Response {
+			// The handler gets its own copy of the request, since m may call next in another goroutine (as
+			// http.TimeoutHandler does), that can outlive r.
+			var (
+				clone    = r.Clone()
+				mutex    sync.Mutex
+				returned bool
+			)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if response := h(clone.WithRequest(req)); response != nil {
+					response.Write(w)
+				}
+
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				if !returned {
+					r.WithRequest(req)
+				}
+			})
+
+			captured := &capturedResponse{header: make(http.Header)}
+
+			m(next).ServeHTTP(captured, r.Base())
+
+			mutex.Lock()
+			returned = true
+			mutex.Unlock()
+
+			return ResponseFunc(captured.writeTo)
+		}
+	}
+}
+
+// ToHTTPMiddleware converts m into a standard net/http middleware, so that it can be used outside a [Router].
+//
+// If m is nil, ToHTTPMiddleware panics.
+func ToHTTPMiddleware(m Middleware) func(http.Handler) http.Handler {
+	if m == nil {
+		panic("m should not be nil")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return m(func(r *Request) Response {
+			return ResponseFunc(func(w http.ResponseWriter) {
+				next.ServeHTTP(w, r.Base())
+			})
+		}).Base()
+	}
+}
+
+// capturedResponse is a http.ResponseWriter that keeps what is written into it, so that it can be written later.
+type capturedResponse struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (c *capturedResponse) Header() http.Header {
+	return c.header
+}
+
+func (c *capturedResponse) WriteHeader(statusCode int) {
+	if c.statusCode == 0 {
+		c.statusCode = statusCode
+	}
+}
+
+func (c *capturedResponse) Write(b []byte) (int, error) {
+	if c.statusCode == 0 {
+		c.statusCode = http.StatusOK
+	}
+
+	return c.body.Write(b)
+}
+
+// Flush does nothing, since the response is only written once it is complete.
+func (c *capturedResponse) Flush() {}
+
+// writeTo writes the captured response into w.
+func (c *capturedResponse) writeTo(w http.ResponseWriter) {
+	maps.Copy(w.Header(), c.header)
+
+	if c.statusCode != 0 {
+		w.WriteHeader(c.statusCode)
+	}
+
+	_, _ = w.Write(c.body.Bytes())
+}
@@ -0,0 +1,221 @@
+package lit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+type upperCaseWriter struct {
+	http.ResponseWriter
+}
+
+func (w upperCaseWriter) Write(b []byte) (int, error) {
+	return w.ResponseWriter.Write([]byte(strings.ToUpper(string(b))))
+}
+
+func TestFromHTTPMiddleware(t *testing.T) {
+	t.Parallel()
+
+	type contextKey string
+
+	handler := func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("user: "))
+
+			if user, ok := r.Context().Value(contextKey("user")).(string); ok {
+				w.Write([]byte(user))
+			}
+		})
+	}
+
+	tests := []struct {
+		description        string
+		middleware         func(http.Handler) http.Handler
+		expectedStatusCode int
+		expectedHeader     http.Header
+		expectedBody       string
+		expectedUser       any
+	}{
+		{
+			description: "WhenMiddlewareChangesRequestContext_ShouldPropagateIt",
+			middleware: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("X-Request-Id", "1")
+					next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey("user"), "john")))
+				})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     http.Header{"Content-Type": {"text/plain"}, "X-Request-Id": {"1"}},
+			expectedBody:       "user: john",
+			expectedUser:       "john",
+		},
+		{
+			description: "WhenMiddlewareDoesNotCallNextHandler_ShouldRespondWhatItWrites",
+			middleware: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "text/plain")
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte("unauthorized"))
+				})
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedHeader:     http.Header{"Content-Type": {"text/plain"}},
+			expectedBody:       "unauthorized",
+		},
+		{
+			description: "WhenMiddlewareWrapsResponseWriter_ShouldRespondWhatWrapperWrites",
+			middleware: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					next.ServeHTTP(upperCaseWriter{w}, r)
+				})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     http.Header{"Content-Type": {"text/plain"}},
+			expectedBody:       "USER: ",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				r        = lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+				recorder = httptest.NewRecorder()
+			)
+
+			// Act
+			response := lit.FromHTTPMiddleware(test.middleware)(handler)(r)
+			response.Write(recorder)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedHeader, recorder.Header())
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedUser, r.Context().Value(contextKey("user")))
+		})
+	}
+}
+
+func TestFromHTTPMiddleware_WhenNextHandlerOutlivesRequest_ShouldKeepItsRequest(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		served = make(chan struct{})
+		late   = make(chan []string, 1)
+	)
+
+	timeout := lit.FromHTTPMiddleware(func(next http.Handler) http.Handler {
+		return http.TimeoutHandler(next, time.Millisecond, "timeout")
+	})
+
+	router := lit.NewRouter()
+	router.GET("/users/:id", func(r *lit.Request) lit.Response {
+		<-r.Context().Done()
+		<-served
+
+		late <- []string{r.URIParameters()["id"], r.URL().Path}
+
+		return nil
+	}, timeout)
+
+	request := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(recorder, request)
+	close(served)
+
+	// Assert
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Equal(t, "timeout", recorder.Body.String())
+	require.Equal(t, []string{"1", "/users/1"}, <-late)
+}
+
+func TestFromHTTPMiddleware_WhenMiddlewareIsNil_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "m should not be nil", func() {
+		lit.FromHTTPMiddleware(nil)
+	})
+}
+
+func TestToHTTPMiddleware(t *testing.T) {
+	t.Parallel()
+
+	middleware := func(h lit.Handler) lit.Handler {
+		return func(r *lit.Request) lit.Response {
+			if r.Header().Get("X-API-Key") == "" {
+				return render.Unauthorized("API Key must be provided")
+			}
+
+			return h(r)
+		}
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	tests := []struct {
+		description        string
+		apiKey             string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description:        "WhenMiddlewareRespondsItself_ShouldWriteResponse",
+			apiKey:             "",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"message":"API Key must be provided"}`,
+		},
+		{
+			description:        "WhenMiddlewareCallsHandler_ShouldCallNextHandler",
+			apiKey:             "key",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				request  = httptest.NewRequest(http.MethodGet, "/users", nil)
+				recorder = httptest.NewRecorder()
+			)
+
+			request.Header.Set("X-API-Key", test.apiKey)
+
+			// Act
+			lit.ToHTTPMiddleware(middleware)(next).ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestToHTTPMiddleware_WhenMiddlewareIsNil_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "m should not be nil", func() {
+		lit.ToHTTPMiddleware(nil)
+	})
+}
@@ -35,6 +35,7 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"net/http"
 	"net/textproto"
 	"reflect"
 	"strconv"
@@ -78,6 +79,11 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%s is not a valid %s: %s", e.Value, e.Target, e.Err)
 }
 
+// StatusCode returns 400 Bad Request, so that handlers that return this error respond it appropriately.
+func (e Error) StatusCode() int {
+	return http.StatusBadRequest
+}
+
 func bind(value string, target reflect.Value) error {
 	switch target.Kind() {
 	case reflect.String:
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 
@@ -16,9 +18,24 @@ import (
 const (
 	formTag = "form"
 	fileTag = "file"
+
+	// maxFormSize is the maximum size of a form body, the same as used by net/http.
+	maxFormSize = 10 << 20
 )
 
-var ErrUnsupportedContentType = errors.New("unsupported Content-Type")
+// ErrUnsupportedContentType is returned when the Content-Type of the request body is not supported.
+var ErrUnsupportedContentType error = unsupportedContentTypeError{}
+
+type unsupportedContentTypeError struct{}
+
+func (e unsupportedContentTypeError) Error() string {
+	return "unsupported Content-Type"
+}
+
+// StatusCode returns 415 Unsupported Media Type, so that handlers that return this error respond it appropriately.
+func (e unsupportedContentTypeError) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
 
 // Body binds the request's body into the fields of a struct of type T.
 //
@@ -104,11 +121,48 @@ func bindForm(r *lit.Request, targetValue reflect.Value) error {
 		return err
 	}
 
+	if err := parseBodyForm(r.Base()); err != nil {
+		return err
+	}
+
 	fields := reflect.VisibleFields(targetValue.Type())
 
 	return bindFields(r.Base().Form, formTag, targetValue, fields, bindAll)
 }
 
+// parseBodyForm parses the form in the body of QUERY requests, since [*http.Request.ParseForm] ignores it. As in
+// ParseForm, body values take precedence over query values.
+func parseBodyForm(r *http.Request) error {
+	if r.Method != lit.MethodQuery {
+		return nil
+	}
+
+	if r.Body == nil {
+		return errors.New("missing form body")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxFormSize+1))
+	if err != nil {
+		return err
+	}
+
+	if len(body) > maxFormSize {
+		return errors.New("form body too large")
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		r.PostForm[key] = append(r.PostForm[key], value...)
+		r.Form[key] = append(value, r.Form[key]...)
+	}
+
+	return nil
+}
+
 func bindMultipartForm(r *lit.Request, targetValue reflect.Value) error {
 	err := r.Base().ParseMultipartForm(32 << 20)
 	if err != nil {
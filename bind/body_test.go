@@ -560,6 +560,27 @@ content of a file
 	}
 }
 
+func TestBody_WhenRequestIsQUERYAndBodyIsNil_ShouldReturnError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	request := httptest.NewRequest(lit.MethodQuery, "/", nil)
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	request.Body = nil
+
+	r := lit.NewRequest(request)
+
+	type RequestBody struct {
+		Name string `form:"name"`
+	}
+
+	// Act
+	_, err := bind.Body[RequestBody](r)
+
+	// Assert
+	require.EqualError(t, err, "missing form body")
+}
+
 func ExampleBody() {
 	req := httptest.NewRequest(http.MethodPost, "/books", strings.NewReader(`
 		{"name": "Percy Jackson", "publishYear": 2009}
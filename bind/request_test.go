@@ -19,6 +19,7 @@ func TestRequest(t *testing.T) {
 
 	tests := []struct {
 		description    string
+		method         string
 		uriParameters  map[string]string
 		query          url.Values
 		header         http.Header
@@ -345,6 +346,44 @@ func TestRequest(t *testing.T) {
 			},
 			expectedResult: bindableFields{Uint: 10, Uint8: 10},
 		},
+		{
+			description: "WhenContentTypeIsForm_AndRequestIsQUERY_ShouldParseFormBodyAndQueryParameters",
+			method:      lit.MethodQuery,
+			query:       url.Values{"uint8": {"10"}},
+			body:        "uint=10",
+			header: http.Header{
+				"Content-Type": {"application/x-www-form-urlencoded"},
+			},
+			function: func(r *lit.Request) (any, error) {
+				return bind.Request[bindableFields](r)
+			},
+			expectedResult: bindableFields{Uint: 10, Uint8: 10},
+		},
+		{
+			description: "WhenContentTypeIsForm_AndRequestIsGET_ShouldIgnoreFormBody",
+			method:      http.MethodGet,
+			query:       url.Values{"uint8": {"10"}},
+			body:        "uint8=20",
+			header: http.Header{
+				"Content-Type": {"application/x-www-form-urlencoded"},
+			},
+			function: func(r *lit.Request) (any, error) {
+				return bind.Request[bindableFields](r)
+			},
+			expectedResult: bindableFields{Uint8: 10},
+		},
+		{
+			description: "WhenContentTypeIsJSON_AndRequestIsQUERY_ShouldParseJSONBody",
+			method:      lit.MethodQuery,
+			body:        `{"uint": 10}`,
+			header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			function: func(r *lit.Request) (any, error) {
+				return bind.Request[bindableFields](r)
+			},
+			expectedResult: bindableFields{Uint: 10},
+		},
 		{
 			description: "WhenContentTypeIsMultipartForm_ShouldParseFormBody",
 			body: `
@@ -386,7 +425,12 @@ Content-Disposition: form-data; name="uint"
 			t.Parallel()
 
 			// Arrange
-			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			method := http.MethodPost
+			if test.method != "" {
+				method = test.method
+			}
+
+			request := httptest.NewRequest(method, "/", strings.NewReader(test.body))
 			request.URL.RawQuery = test.query.Encode()
 			for key, value := range test.header {
 				for _, v := range value {
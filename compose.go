@@ -0,0 +1,139 @@
+package lit
+
+import (
+	"net/http"
+	"path"
+	"slices"
+)
+
+// Predicate reports whether a request satisfies a condition.
+type Predicate func(r *Request) bool
+
+// When transforms m so that it is only applied to requests that satisfy predicate. Other requests are handled
+// directly by the transformed handler.
+//
+// For example, the middleware below authenticates every request, except the health checks:
+//
+//	When(Not(PathMatches("/healthz", "/metrics")), Authenticate)
+//
+// If predicate or m is nil, When panics.
+func When(predicate Predicate, m Middleware) Middleware {
+	if predicate == nil {
+		panic("predicate should not be nil")
+	}
+
+	if m == nil {
+		panic("m should not be nil")
+	}
+
+	return func(h Handler) Handler {
+		transformed := m(h)
+
+		return func(r *Request) Response {
+			if predicate(r) {
+				return transformed(r)
+			}
+
+			return h(r)
+		}
+	}
+}
+
+// Unless transforms m so that it is not applied to requests whose path matches the glob pattern (see [path.Match]).
+//
+// It's equivalent to:
+//
+//	When(Not(PathMatches(pattern)), m)
+//
+// If pattern is malformed or m is nil, Unless panics.
+func Unless(pattern string, m Middleware) Middleware {
+	return When(Not(PathMatches(pattern)), m)
+}
+
+// Chain combines middlewares into a single [Middleware], applied in the same order as if they were registered one
+// by one. For example,
+//
+//	Chain(M1, M2)(h)
+//
+// is equivalent to M1(M2(h)).
+//
+// If a middleware is nil, Chain panics.
+func Chain(middlewares ...Middleware) Middleware {
+	if slices.ContainsFunc(middlewares, func(m Middleware) bool { return m == nil }) {
+		panic("middlewares should not be nil")
+	}
+
+	middlewares = slices.Clone(middlewares)
+
+	return func(h Handler) Handler {
+		return transform(h, middlewares)
+	}
+}
+
+// Not returns a [Predicate] that is satisfied by requests that don't satisfy predicate.
+//
+// If predicate is nil, Not panics.
+func Not(predicate Predicate) Predicate {
+	if predicate == nil {
+		panic("predicate should not be nil")
+	}
+
+	return func(r *Request) bool {
+		return !predicate(r)
+	}
+}
+
+// MethodIs returns a [Predicate] that is satisfied by requests whose method is one of methods.
+func MethodIs(methods ...string) Predicate {
+	methods = slices.Clone(methods)
+
+	return func(r *Request) bool {
+		return slices.Contains(methods, r.Method())
+	}
+}
+
+// PathMatches returns a [Predicate] that is satisfied by requests whose URL path matches one of the glob patterns
+// (see [path.Match]). Note that "*" does not match slashes, so "/api/*" matches "/api/users", but not
+// "/api/users/1".
+//
+// If a pattern is malformed, PathMatches panics.
+func PathMatches(patterns ...string) Predicate {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic("pattern '" + pattern + "' is malformed: " + err.Error())
+		}
+	}
+
+	patterns = slices.Clone(patterns)
+
+	return func(r *Request) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			matched, _ := path.Match(pattern, r.URL().Path)
+			return matched
+		})
+	}
+}
+
+// PatternIs returns a [Predicate] that is satisfied by requests whose matched route was registered with one of
+// patterns, such as "/users/:id". Requests that have not matched a route don't satisfy it.
+func PatternIs(patterns ...string) Predicate {
+	patterns = slices.Clone(patterns)
+
+	return func(r *Request) bool {
+		route := r.Route()
+		return route.Method != "" && slices.Contains(patterns, route.Pattern)
+	}
+}
+
+// HeaderIs returns a [Predicate] that is satisfied by requests whose header field key has value. If value is empty,
+// it is satisfied by requests that have the field, regardless of its value.
+func HeaderIs(key string, value string) Predicate {
+	return func(r *Request) bool {
+		values, ok := r.Header()[http.CanonicalHeaderKey(key)]
+		if value == "" {
+			return ok
+		}
+
+		return slices.Contains(values, value)
+	}
+}
@@ -0,0 +1,210 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+// appendToBody is a middleware that appends name to the response body after the handler writes it.
+func appendToBody(name string) lit.Middleware {
+	return func(h lit.Handler) lit.Handler {
+		return func(r *lit.Request) lit.Response {
+			response := h(r)
+
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				response.Write(w)
+				w.Write([]byte(" " + name))
+			})
+		}
+	}
+}
+
+func TestRouter_ServeHTTP_ComposedMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			w.Write([]byte("handler"))
+		})
+	}
+
+	tests := []struct {
+		description  string
+		middleware   lit.Middleware
+		method       string
+		path         string
+		header       http.Header
+		expectedBody string
+	}{
+		{
+			description:  "When_WhenPredicateIsSatisfied_ShouldApplyMiddleware",
+			middleware:   lit.When(lit.MethodIs(http.MethodPost, http.MethodPut), appendToBody("m")),
+			method:       http.MethodPost,
+			path:         "/users",
+			expectedBody: "handler m",
+		},
+		{
+			description:  "When_WhenPredicateIsNotSatisfied_ShouldNotApplyMiddleware",
+			middleware:   lit.When(lit.MethodIs(http.MethodPost, http.MethodPut), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users",
+			expectedBody: "handler",
+		},
+		{
+			description:  "When_GivenPatternPredicate_WhenRouteHasPattern_ShouldApplyMiddleware",
+			middleware:   lit.When(lit.PatternIs("/users/:id"), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users/1",
+			expectedBody: "handler m",
+		},
+		{
+			description:  "When_GivenPatternPredicate_WhenRouteHasAnotherPattern_ShouldNotApplyMiddleware",
+			middleware:   lit.When(lit.PatternIs("/users/:id"), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users",
+			expectedBody: "handler",
+		},
+		{
+			description:  "When_GivenHeaderPredicate_WhenHeaderHasValue_ShouldApplyMiddleware",
+			middleware:   lit.When(lit.HeaderIs("x-debug", "true"), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users",
+			header:       http.Header{"X-Debug": {"true"}},
+			expectedBody: "handler m",
+		},
+		{
+			description:  "When_GivenHeaderPredicate_WhenHeaderHasAnotherValue_ShouldNotApplyMiddleware",
+			middleware:   lit.When(lit.HeaderIs("X-Debug", "true"), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users",
+			header:       http.Header{"X-Debug": {"false"}},
+			expectedBody: "handler",
+		},
+		{
+			description:  "When_GivenHeaderPresencePredicate_WhenHeaderIsPresent_ShouldApplyMiddleware",
+			middleware:   lit.When(lit.HeaderIs("X-Debug", ""), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users",
+			header:       http.Header{"X-Debug": {"false"}},
+			expectedBody: "handler m",
+		},
+		{
+			description:  "When_GivenNegatedPredicate_ShouldApplyMiddlewareIfNotSatisfied",
+			middleware:   lit.When(lit.Not(lit.PathMatches("/healthz", "/metrics")), appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/metrics",
+			expectedBody: "handler",
+		},
+		{
+			description:  "Unless_WhenPathMatchesGlob_ShouldNotApplyMiddleware",
+			middleware:   lit.Unless("/users/*", appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users/1",
+			expectedBody: "handler",
+		},
+		{
+			description:  "Unless_WhenPathDoesNotMatchGlob_ShouldApplyMiddleware",
+			middleware:   lit.Unless("/users/*", appendToBody("m")),
+			method:       http.MethodGet,
+			path:         "/users",
+			expectedBody: "handler m",
+		},
+		{
+			description:  "Chain_ShouldApplyMiddlewaresInOrder",
+			middleware:   lit.Chain(appendToBody("m1"), appendToBody("m2"), appendToBody("m3")),
+			method:       http.MethodGet,
+			path:         "/users",
+			expectedBody: "handler m3 m2 m1",
+		},
+		{
+			description:  "Chain_WhenMiddlewaresAreEmpty_ShouldNotTransformHandler",
+			middleware:   lit.Chain(),
+			method:       http.MethodGet,
+			path:         "/users",
+			expectedBody: "handler",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				router   = lit.NewRouter()
+				request  = httptest.NewRequest(test.method, test.path, nil)
+				recorder = httptest.NewRecorder()
+			)
+
+			for key, values := range test.header {
+				request.Header[key] = values
+			}
+
+			router.Use(test.middleware)
+
+			for _, path := range []string{"/users", "/users/:id", "/healthz", "/metrics"} {
+				router.GET(path, handler)
+				router.POST(path, handler)
+			}
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedBody, strings.TrimSpace(recorder.Body.String()))
+		})
+	}
+}
+
+func TestCompose_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	middleware := func(h lit.Handler) lit.Handler { return h }
+
+	tests := []struct {
+		description string
+		function    func()
+		panicValue  string
+	}{
+		{
+			description: "When_WhenPredicateIsNil_ShouldPanic",
+			function:    func() { lit.When(nil, middleware) },
+			panicValue:  "predicate should not be nil",
+		},
+		{
+			description: "When_WhenMiddlewareIsNil_ShouldPanic",
+			function:    func() { lit.When(lit.MethodIs(http.MethodGet), nil) },
+			panicValue:  "m should not be nil",
+		},
+		{
+			description: "Unless_WhenPatternIsMalformed_ShouldPanic",
+			function:    func() { lit.Unless("/users/[", middleware) },
+			panicValue:  "pattern '/users/[' is malformed: syntax error in pattern",
+		},
+		{
+			description: "Chain_WhenMiddlewaresContainsANilElement_ShouldPanic",
+			function:    func() { lit.Chain(middleware, nil) },
+			panicValue:  "middlewares should not be nil",
+		},
+		{
+			description: "Not_WhenPredicateIsNil_ShouldPanic",
+			function:    func() { lit.Not(nil) },
+			panicValue:  "predicate should not be nil",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.panicValue, test.function)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package compress contains a middleware that compresses the bodies of responses, with the content coding
+// negotiated by the Accept-Encoding header of the requests:
+//
+//	r.Use(compress.New())
+//
+// It supports gzip and deflate by default. Other encodings, such as Zstandard, can be added with [Register].
+//
+// Bodies smaller than a threshold (see [WithMinSize]), responses whose content type is already compressed (such as
+// images, videos and archives), partial responses and responses that already have a Content-Encoding are sent
+// uncompressed.
+package compress
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jvcoutinho/lit"
+)
+
+// defaultMinSize is the default minimum size of the bodies that are compressed, in bytes.
+const defaultMinSize = 1024
+
+// Option configures the middleware created by [New].
+type Option func(c *config)
+
+type config struct {
+	minSize   int
+	names     []string
+	encodings []*encoding
+}
+
+// WithMinSize sets the minimum size of the bodies that are compressed, in bytes. Smaller bodies are sent
+// uncompressed, since compression would not pay off. By default, it's 1024.
+//
+// If size is negative, WithMinSize panics.
+func WithMinSize(size int) Option {
+	if size < 0 {
+		panic("size should not be negative")
+	}
+
+	return func(c *config) {
+		c.minSize = size
+	}
+}
+
+// WithEncodings sets the encodings that can be used, in order of preference. By default, every registered encoding
+// can be used (see [Register]).
+//
+// If an encoding is not registered when the middleware is created, [New] panics.
+func WithEncodings(names ...string) Option {
+	return func(c *config) {
+		c.names = names
+	}
+}
+
+// New creates a middleware that compresses the bodies of responses, configured by options.
+//
+// The Vary header of every response includes Accept-Encoding. Compressed responses have the Content-Encoding header
+// set and the Content-Length header removed. If their Content-Type is not set, it's detected from the uncompressed
+// body.
+func New(options ...Option) lit.Middleware {
+	c := &config{minSize: defaultMinSize}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	c.encodings = encodings(c.names)
+
+	return func(h lit.Handler) lit.Handler {
+		return func(r *lit.Request) lit.Response {
+			res := h(r)
+
+			if res == nil {
+				return nil
+			}
+
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Header().Add("Vary", "Accept-Encoding")
+
+				encoding := c.negotiate(r.Header().Values("Accept-Encoding"))
+				if encoding == nil || r.Method() == http.MethodHead {
+					res.Write(w)
+					return
+				}
+
+				writer := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: c.minSize}
+				defer writer.close()
+
+				res.Write(writer)
+			})
+		}
+	}
+}
+
+// negotiate returns the encoding with the highest quality value in the Accept-Encoding header values, or nil if
+// none of the encodings are acceptable.
+func (c *config) negotiate(values []string) *encoding {
+	if len(values) == 0 {
+		return nil
+	}
+
+	accepted := parseAcceptEncoding(values)
+
+	var (
+		selected *encoding
+		quality  float64
+	)
+
+	for _, encoding := range c.encodings {
+		q, ok := accepted[encoding.name]
+		if !ok {
+			q = accepted["*"]
+		}
+
+		if q > quality {
+			selected, quality = encoding, q
+		}
+	}
+
+	return selected
+}
+
+// parseAcceptEncoding returns the quality values of the codings in the Accept-Encoding header values.
+func parseAcceptEncoding(values []string) map[string]float64 {
+	accepted := make(map[string]float64)
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			coding, parameters, _ := strings.Cut(item, ";")
+			coding = strings.ToLower(strings.TrimSpace(coding))
+
+			if coding == "" {
+				continue
+			}
+
+			q := 1.0
+
+			parameter, found := strings.CutPrefix(strings.TrimSpace(parameters), "q=")
+			if found {
+				var err error
+				if q, err = strconv.ParseFloat(parameter, 64); err != nil {
+					q = 0
+				}
+			}
+
+			accepted[coding] = q
+		}
+	}
+
+	return accepted
+}
@@ -0,0 +1,354 @@
+package compress_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/compress"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+var largeBody = strings.Repeat("lit ", 512)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description      string
+		options          []compress.Option
+		method           string
+		acceptEncoding   string
+		requestHeader    http.Header
+		handler          lit.Handler
+		expectedEncoding string
+		expectedHeader   http.Header
+		expectedBody     string
+	}{
+		{
+			description:    "WhenAcceptEncodingIsNotSet_ShouldNotCompress",
+			acceptEncoding: "",
+			handler:        text(largeBody),
+			expectedHeader: http.Header{
+				"Content-Type": {"text/plain"},
+				"Vary":         {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:      "WhenGzipIsAccepted_ShouldCompressWithGzip",
+			acceptEncoding:   "gzip",
+			handler:          text(largeBody),
+			expectedEncoding: "gzip",
+			expectedHeader: http.Header{
+				"Content-Type":     {"text/plain"},
+				"Content-Encoding": {"gzip"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:      "WhenDeflateIsPreferred_ShouldCompressWithDeflate",
+			acceptEncoding:   "gzip;q=0.5, deflate",
+			handler:          text(largeBody),
+			expectedEncoding: "deflate",
+			expectedHeader: http.Header{
+				"Content-Type":     {"text/plain"},
+				"Content-Encoding": {"deflate"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:      "WhenEncodingsAreEquallyAccepted_ShouldUseServerPreference",
+			options:          []compress.Option{compress.WithEncodings("deflate", "gzip")},
+			acceptEncoding:   "gzip, deflate, br",
+			handler:          text(largeBody),
+			expectedEncoding: "deflate",
+			expectedHeader: http.Header{
+				"Content-Type":     {"text/plain"},
+				"Content-Encoding": {"deflate"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:      "WhenWildcardIsAccepted_ShouldCompress",
+			acceptEncoding:   "*",
+			handler:          text(largeBody),
+			expectedEncoding: "gzip",
+			expectedHeader: http.Header{
+				"Content-Type":     {"text/plain"},
+				"Content-Encoding": {"gzip"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:    "WhenEncodingsAreRejected_ShouldNotCompress",
+			acceptEncoding: "gzip;q=0, deflate;q=0, br",
+			handler:        text(largeBody),
+			expectedHeader: http.Header{
+				"Content-Type": {"text/plain"},
+				"Vary":         {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:    "WhenBodyIsSmall_ShouldNotCompress",
+			acceptEncoding: "gzip",
+			handler: func(r *lit.Request) lit.Response {
+				return render.OK("small")
+			},
+			expectedHeader: http.Header{
+				"Content-Type": {"application/json"},
+				"Vary":         {"Accept-Encoding"},
+			},
+			expectedBody: `{"message":"small"}`,
+		},
+		{
+			description:      "WhenMinSizeIsSet_ShouldUseIt",
+			options:          []compress.Option{compress.WithMinSize(0)},
+			acceptEncoding:   "gzip",
+			handler:          func(r *lit.Request) lit.Response { return render.OK("small") },
+			expectedEncoding: "gzip",
+			expectedHeader: http.Header{
+				"Content-Type":     {"application/json"},
+				"Content-Encoding": {"gzip"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: `{"message":"small"}`,
+		},
+		{
+			description:    "WhenContentTypeIsCompressed_ShouldNotCompress",
+			acceptEncoding: "gzip",
+			handler: func(r *lit.Request) lit.Response {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					w.Header().Set("Content-Type", "image/png")
+					w.Write([]byte(largeBody))
+				})
+			},
+			expectedHeader: http.Header{
+				"Content-Type": {"image/png"},
+				"Vary":         {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:      "WhenContentTypeIsNotSet_ShouldDetectIt",
+			acceptEncoding:   "gzip",
+			handler:          writeString(largeBody),
+			expectedEncoding: "gzip",
+			expectedHeader: http.Header{
+				"Content-Type":     {"text/plain; charset=utf-8"},
+				"Content-Encoding": {"gzip"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:    "WhenResponseIsEncoded_ShouldNotCompress",
+			acceptEncoding: "gzip",
+			handler: func(r *lit.Request) lit.Response {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					w.Header().Set("Content-Encoding", "br")
+					w.Write([]byte(largeBody))
+				})
+			},
+			expectedHeader: http.Header{
+				"Content-Encoding": {"br"},
+				"Vary":             {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+		{
+			description:    "WhenResponseIsPartial_ShouldNotCompress",
+			acceptEncoding: "gzip",
+			requestHeader:  http.Header{"Range": {"bytes=0-3"}},
+			handler: func(r *lit.Request) lit.Response {
+				return render.Stream(r, strings.NewReader(largeBody)).WithFilePath("lit.txt")
+			},
+			expectedHeader: http.Header{
+				"Accept-Ranges":  {"bytes"},
+				"Content-Length": {"4"},
+				"Content-Range":  {"bytes 0-3/2048"},
+				"Content-Type":   {"text/plain; charset=utf-8"},
+				"Vary":           {"Accept-Encoding"},
+			},
+			expectedBody: "lit ",
+		},
+		{
+			description:    "WhenResponseHasNoContent_ShouldNotCompress",
+			acceptEncoding: "gzip",
+			handler: func(r *lit.Request) lit.Response {
+				return render.NoContent()
+			},
+			expectedHeader: http.Header{"Vary": {"Accept-Encoding"}},
+		},
+		{
+			description:    "WhenMethodIsHEAD_ShouldNotCompress",
+			method:         http.MethodHead,
+			acceptEncoding: "gzip",
+			handler:        text(largeBody),
+			expectedHeader: http.Header{
+				"Content-Type": {"text/plain"},
+				"Vary":         {"Accept-Encoding"},
+			},
+			expectedBody: largeBody,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			router := lit.NewRouter()
+			router.Use(compress.New(test.options...))
+			router.Handle("/", method, test.handler)
+
+			request := httptest.NewRequest(method, "/", nil)
+			if test.acceptEncoding != "" {
+				request.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+
+			for key, values := range test.requestHeader {
+				request.Header[key] = values
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedHeader, recorder.Header())
+			require.Equal(t, test.expectedBody, decode(t, test.expectedEncoding, recorder.Body))
+		})
+	}
+}
+
+func TestNew_WhenResponseIsFlushed_ShouldSendCompressedBody(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	flushed := make(chan []byte, 1)
+
+	handler := func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("data: lit\n\n"))
+			w.(http.Flusher).Flush()
+
+			flushed <- w.(interface{ Unwrap() http.ResponseWriter }).Unwrap().(*httptest.ResponseRecorder).Body.Bytes()
+		})
+	}
+
+	router := lit.NewRouter()
+	router.Use(compress.New())
+	router.GET("/events", handler)
+
+	request := httptest.NewRequest(http.MethodGet, "/events", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	recorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	require.True(t, recorder.Flushed)
+	require.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(bytes.NewReader(<-flushed))
+	require.NoError(t, err)
+
+	message := make([]byte, len("data: lit\n\n"))
+	_, err = io.ReadFull(reader, message)
+	require.NoError(t, err)
+	require.Equal(t, "data: lit\n\n", string(message))
+}
+
+func TestOptions_WhenArgumentsAreInvalid_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description   string
+		function      func()
+		expectedPanic string
+	}{
+		{
+			description:   "NegativeMinSize",
+			function:      func() { compress.WithMinSize(-1) },
+			expectedPanic: "size should not be negative",
+		},
+		{
+			description:   "UnknownEncoding",
+			function:      func() { compress.New(compress.WithEncodings("gzip", "lzma")) },
+			expectedPanic: "encoding 'lzma' is not registered",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.expectedPanic, test.function)
+		})
+	}
+}
+
+func text(body string) lit.Handler {
+	return func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(body))
+		})
+	}
+}
+
+func writeString(body string) lit.Handler {
+	return func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			io.WriteString(w, body)
+		})
+	}
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) string {
+	t.Helper()
+
+	var (
+		reader io.Reader
+		err    error
+	)
+
+	switch encoding {
+	case "gzip":
+		reader, err = gzip.NewReader(body)
+		require.NoError(t, err)
+	case "deflate":
+		reader = flate.NewReader(body)
+	default:
+		reader = body
+	}
+
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(decoded)
+}
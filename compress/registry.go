@@ -0,0 +1,114 @@
+package compress
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Encoder creates a writer that compresses the data written to it into w. Closing the writer must flush the pending
+// data, without closing w.
+//
+// If the writer has a "Flush() error" method, it's called when the response is flushed, so that streaming responses
+// are sent as they are written. If it has a "Reset(io.Writer)" method, writers are reused between responses.
+type Encoder func(w io.Writer) io.WriteCloser
+
+var registry = struct {
+	sync.RWMutex
+	names    []string
+	encoders map[string]Encoder
+}{
+	names: []string{"gzip", "deflate"},
+	encoders: map[string]Encoder{
+		"gzip": func(w io.Writer) io.WriteCloser {
+			return gzip.NewWriter(w)
+		},
+		"deflate": func(w io.Writer) io.WriteCloser {
+			writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return writer
+		},
+	},
+}
+
+// Register makes encoder available for the content coding name (as in Accept-Encoding), replacing the encoder
+// previously registered for it. For example, to support Zstandard:
+//
+//	compress.Register("zstd", func(w io.Writer) io.WriteCloser {
+//		encoder, _ := zstd.NewWriter(w)
+//		return encoder
+//	})
+//
+// Encoders for "gzip" and "deflate" are registered by default. The encodings are preferred in the order they were
+// first registered when clients accept several of them equally. Middlewares created before the registration are not
+// affected.
+//
+// If name is empty or encoder is nil, Register panics.
+func Register(name string, encoder Encoder) {
+	if name == "" {
+		panic("name should not be empty")
+	}
+
+	if encoder == nil {
+		panic("encoder should not be nil")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.encoders[name]; !ok {
+		registry.names = append(registry.names, name)
+	}
+
+	registry.encoders[name] = encoder
+}
+
+// encodings returns the registered encodings with the given names, or all of them if names is empty.
+func encodings(names []string) []*encoding {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if len(names) == 0 {
+		names = slices.Clone(registry.names)
+	}
+
+	encodings := make([]*encoding, len(names))
+
+	for i, name := range names {
+		encoder, ok := registry.encoders[name]
+		if !ok {
+			panic("encoding '" + name + "' is not registered")
+		}
+
+		encodings[i] = &encoding{name: name, encoder: encoder}
+	}
+
+	return encodings
+}
+
+// encoding is a content coding whose writers are pooled, if they can be reset.
+type encoding struct {
+	name    string
+	encoder Encoder
+	pool    sync.Pool
+}
+
+type resetter interface {
+	Reset(w io.Writer)
+}
+
+func (e *encoding) writer(w io.Writer) io.WriteCloser {
+	if writer, ok := e.pool.Get().(io.WriteCloser); ok {
+		writer.(resetter).Reset(w)
+		return writer
+	}
+
+	return e.encoder(w)
+}
+
+func (e *encoding) release(writer io.WriteCloser) {
+	if _, ok := writer.(resetter); ok {
+		e.pool.Put(writer)
+	}
+}
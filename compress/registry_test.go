@@ -0,0 +1,70 @@
+package compress_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/compress"
+	"github.com/stretchr/testify/require"
+)
+
+// upperWriter is a toy encoder that converts ASCII letters to upper case.
+type upperWriter struct {
+	io.Writer
+}
+
+func (w upperWriter) Write(b []byte) (int, error) {
+	upper := make([]byte, len(b))
+	for i, c := range b {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+
+		upper[i] = c
+	}
+
+	return w.Writer.Write(upper)
+}
+
+func (w upperWriter) Close() error {
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	compress.Register("x-upper", func(w io.Writer) io.WriteCloser {
+		return upperWriter{w}
+	})
+
+	router := lit.NewRouter()
+	router.Use(compress.New(compress.WithEncodings("x-upper"), compress.WithMinSize(0)))
+	router.GET("/", text("lit"))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Encoding", "gzip, x-upper")
+
+	recorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	require.Equal(t, "x-upper", recorder.Header().Get("Content-Encoding"))
+	require.Equal(t, "LIT", recorder.Body.String())
+}
+
+func TestRegister_WhenArgumentsAreInvalid_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "name should not be empty", func() {
+		compress.Register("", func(w io.Writer) io.WriteCloser { return upperWriter{w} })
+	})
+	require.PanicsWithValue(t, "encoder should not be nil", func() {
+		compress.Register("x-upper", nil)
+	})
+}
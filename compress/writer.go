@@ -0,0 +1,196 @@
+package compress
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// compressedTypes are the media types of content that is already compressed.
+var compressedTypes = []string{
+	"application/gzip",
+	"application/x-gzip",
+	"application/zip",
+	"application/zstd",
+	"application/x-bzip2",
+	"application/x-xz",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"font/woff",
+	"font/woff2",
+}
+
+// compressWriter is a http.ResponseWriter that compresses the body with an encoding. The body is buffered until its
+// size reaches the minimum size, so that small bodies are sent uncompressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding   *encoding
+	minSize    int
+	statusCode int
+	buffer     []byte
+	committed  bool
+	writer     io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(statusCode int) {
+	if w.committed || (statusCode >= 100 && statusCode <= 199) {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.committed {
+		if w.writer != nil {
+			return w.writer.Write(b)
+		}
+
+		return w.ResponseWriter.Write(b)
+	}
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	if !w.compressible() {
+		if err := w.commit(false); err != nil {
+			return 0, err
+		}
+
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+
+	if len(w.buffer) >= w.minSize {
+		if err := w.commit(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// Flush sends the buffered body compressed, since the size of the body can't be known anymore.
+func (w *compressWriter) Flush() {
+	if !w.committed {
+		if w.statusCode == 0 {
+			w.statusCode = http.StatusOK
+		}
+
+		if err := w.commit(w.compressible()); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := w.writer.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close sends the buffered body uncompressed, if the response has not been committed, or finishes the compressed body.
+func (w *compressWriter) close() {
+	if !w.committed {
+		if w.statusCode == 0 {
+			return
+		}
+
+		_ = w.commit(false)
+	}
+
+	if w.writer != nil {
+		_ = w.writer.Close()
+		w.encoding.release(w.writer)
+		w.writer = nil
+	}
+}
+
+// compressible reports whether the response can be compressed, given its status code and headers.
+func (w *compressWriter) compressible() bool {
+	switch w.statusCode {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+
+	header := w.Header()
+
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+
+	return contentType == "" || !isCompressed(contentType)
+}
+
+// commit writes the header and the buffered body, compressing it if compress is true and the detected content type
+// is not compressed.
+func (w *compressWriter) commit(compress bool) error {
+	w.committed = true
+
+	header := w.Header()
+
+	if compress && header.Get("Content-Type") == "" && len(w.buffer) > 0 {
+		contentType := http.DetectContentType(w.buffer)
+		header.Set("Content-Type", contentType)
+		compress = !isCompressed(contentType)
+	}
+
+	if compress {
+		header.Set("Content-Encoding", w.encoding.name)
+		header.Del("Content-Length")
+		w.writer = w.encoding.writer(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.statusCode)
+
+	buffer := w.buffer
+	w.buffer = nil
+
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	if w.writer != nil {
+		_, err := w.writer.Write(buffer)
+		return err
+	}
+
+	_, err := w.ResponseWriter.Write(buffer)
+
+	return err
+}
+
+func isCompressed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "audio/"):
+		return true
+	}
+
+	return slices.Contains(compressedTypes, mediaType)
+}
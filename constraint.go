@@ -0,0 +1,181 @@
+package lit
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Constraint restricts the values a URI parameter can assume.
+//
+// A constraint is declared in a route pattern right after the parameter name, between angle brackets, optionally
+// followed by an argument after a colon. For example, in the pattern
+//
+//	/files/:name<regex:[a-z]+\.txt>
+//
+// the parameter "name" has the constraint "regex" with argument `[a-z]+\.txt`.
+//
+// A Constraint receives the argument (that can be empty) and returns a function that reports whether a value
+// satisfies it. If the argument is invalid, it returns an error.
+type Constraint func(argument string) (func(value string) bool, error)
+
+// Built-in constraints, available in every [Router]:
+//
+//   - "int": signed integers in base 10;
+//   - "uint": unsigned integers in base 10;
+//   - "float": floating-point numbers;
+//   - "bool": boolean values, as accepted by [strconv.ParseBool];
+//   - "alpha": one or more ASCII letters;
+//   - "alphanumeric": one or more ASCII letters or digits;
+//   - "uuid": UUIDs of any version;
+//   - "regex": values that fully match the regular expression passed as argument.
+var defaultConstraints = map[string]Constraint{
+	"int":          parserConstraint(func(value string) error { _, err := strconv.ParseInt(value, 10, 64); return err }),
+	"uint":         parserConstraint(func(value string) error { _, err := strconv.ParseUint(value, 10, 64); return err }),
+	"float":        parserConstraint(func(value string) error { _, err := strconv.ParseFloat(value, 64); return err }),
+	"bool":         parserConstraint(func(value string) error { _, err := strconv.ParseBool(value); return err }),
+	"alpha":        regexConstraint(`[a-zA-Z]+`),
+	"alphanumeric": regexConstraint(`[a-zA-Z0-9]+`),
+	"uuid":         regexConstraint(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`),
+	"regex":        regex,
+}
+
+type parameterConstraint struct {
+	parameter string
+	satisfies func(value string) bool
+}
+
+// RegisterConstraint registers constraint under name, so that it can be used in the patterns of routes registered
+// afterward. If there is already a constraint registered with name, including the built-in ones, it is replaced.
+//
+// Check [Constraint] for more details.
+//
+// If name is empty, constraint is nil or r has already started serving requests, RegisterConstraint panics.
+func (r *Router) RegisterConstraint(name string, constraint Constraint) {
+	if name == "" {
+		panic("name should not be empty")
+	}
+
+	if constraint == nil {
+		panic("constraint should not be nil")
+	}
+
+	r.checkNotFrozen()
+
+	r.constraints[name] = constraint
+}
+
+// HandleConstraintMismatch registers handler to be called when a request matches a route, but one of its URI
+// parameters does not satisfy the constraint declared in the route pattern. By default, Lit calls the Not Found
+// handler (either the one defined in HandleNotFound or the default one).
+//
+// Unless disabled by [WithFallbackMiddlewares], global middlewares are applied to handler.
+//
+// If handler is nil, HandleConstraintMismatch restores the default behaviour.
+//
+// If r has already started serving requests, HandleConstraintMismatch panics.
+func (r *Router) HandleConstraintMismatch(handler Handler) {
+	r.checkNotFrozen()
+
+	r.constraintMismatch = handler
+}
+
+// parsePattern removes the constraints from pattern, returning them along with the resulting pattern.
+func (r *Router) parsePattern(pattern string) (string, []parameterConstraint) {
+	var (
+		path        = &strings.Builder{}
+		constraints []parameterConstraint
+	)
+
+	for {
+		i := strings.IndexAny(pattern, ":*")
+		if i < 0 {
+			path.WriteString(pattern)
+			return path.String(), constraints
+		}
+
+		end := i + strings.IndexAny(pattern[i:]+"/", "/<")
+		path.WriteString(pattern[:end])
+
+		parameter := pattern[i+1 : end]
+		pattern = pattern[end:]
+
+		if !strings.HasPrefix(pattern, "<") {
+			continue
+		}
+
+		closing := constraintEnd(pattern)
+		if closing < 0 {
+			panic("constraint of parameter '" + parameter + "' should end with '>'")
+		}
+
+		constraints = append(constraints, parameterConstraint{
+			parameter,
+			r.buildConstraint(parameter, pattern[1:closing]),
+		})
+
+		pattern = pattern[closing+1:]
+	}
+}
+
+func (r *Router) buildConstraint(parameter string, declaration string) func(value string) bool {
+	name, argument, _ := strings.Cut(declaration, ":")
+
+	constraint, ok := r.constraints[name]
+	if !ok {
+		panic("unknown constraint '" + name + "' for parameter '" + parameter + "'")
+	}
+
+	satisfies, err := constraint(argument)
+	if err != nil {
+		panic(fmt.Sprintf("invalid constraint '%s' for parameter '%s': %s", declaration, parameter, err))
+	}
+
+	return satisfies
+}
+
+// constraintEnd returns the index of the '>' that closes a constraint, that is, the first one followed by a slash
+// or by the end of the pattern.
+func constraintEnd(pattern string) int {
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '>' && (i == len(pattern)-1 || pattern[i+1] == '/') {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func satisfiesConstraints(constraints []parameterConstraint, parameters map[string]string) bool {
+	for _, constraint := range constraints {
+		if !constraint.satisfies(parameters[constraint.parameter]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parserConstraint(parse func(value string) error) Constraint {
+	return func(_ string) (func(value string) bool, error) {
+		return func(value string) bool {
+			return parse(value) == nil
+		}, nil
+	}
+}
+
+func regexConstraint(expression string) Constraint {
+	return func(_ string) (func(value string) bool, error) {
+		return regex(expression)
+	}
+}
+
+func regex(expression string) (func(value string) bool, error) {
+	compiled, err := regexp.Compile("^(?:" + expression + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	return compiled.MatchString, nil
+}
@@ -0,0 +1,276 @@
+package lit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_RegisterConstraint(t *testing.T) {
+	t.Parallel()
+
+	constraint := func(argument string) (func(string) bool, error) {
+		return func(value string) bool { return true }, nil
+	}
+
+	tests := []struct {
+		description string
+		name        string
+		constraint  lit.Constraint
+		panicValue  string
+	}{
+		{
+			description: "WhenNameIsEmpty_ShouldPanic",
+			name:        "",
+			constraint:  constraint,
+			panicValue:  "name should not be empty",
+		},
+		{
+			description: "WhenConstraintIsNil_ShouldPanic",
+			name:        "even",
+			constraint:  nil,
+			panicValue:  "constraint should not be nil",
+		},
+		{
+			description: "WhenNameAndConstraintAreValid_ShouldNotPanic",
+			name:        "even",
+			constraint:  constraint,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+
+			// Act
+			// Assert
+			if test.panicValue != "" {
+				require.PanicsWithValue(t, test.panicValue, func() {
+					router.RegisterConstraint(test.name, test.constraint)
+				})
+
+				return
+			}
+
+			require.NotPanics(t, func() {
+				router.RegisterConstraint(test.name, test.constraint)
+			})
+		})
+	}
+}
+
+func TestRouter_Handle_Constraints(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response { return nil }
+
+	tests := []struct {
+		description string
+		path        string
+		panicValue  string
+	}{
+		{
+			description: "WhenConstraintIsUnknown_ShouldPanic",
+			path:        "/users/:id<number>",
+			panicValue:  "unknown constraint 'number' for parameter 'id'",
+		},
+		{
+			description: "WhenConstraintIsNotClosed_ShouldPanic",
+			path:        "/users/:id<int",
+			panicValue:  "constraint of parameter 'id' should end with '>'",
+		},
+		{
+			description: "WhenConstraintArgumentIsInvalid_ShouldPanic",
+			path:        "/users/:name<regex:[a-z>",
+			panicValue: "invalid constraint 'regex:[a-z' for parameter 'name': " +
+				"error parsing regexp: missing closing ]: `[a-z)$`",
+		},
+		{
+			description: "WhenConstraintIsValid_ShouldRegisterPatternWithoutIt",
+			path:        "/users/:id<int>",
+			panicValue:  "a handle is already registered for path '/users/:id'",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+
+			// Act
+			// Assert
+			require.PanicsWithValue(t, test.panicValue, func() {
+				router.GET(test.path, handler)
+
+				// Attempting to handle again in order to deliberately create a panic. This will only happen
+				// if the handler is successfully registered.
+				router.GET(test.path, handler)
+			})
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_Constraints(t *testing.T) {
+	t.Parallel()
+
+	var (
+		printParametersHandler = func(r *lit.Request) lit.Response {
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				for _, key := range []string{"id", "name", "path"} {
+					if value, ok := r.URIParameters()[key]; ok {
+						w.Write([]byte(key + "=" + value + ";"))
+					}
+				}
+			})
+		}
+
+		badRequestHandler = func(r *lit.Request) lit.Response {
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				http.Error(w, "invalid parameters", http.StatusBadRequest)
+			})
+		}
+
+		evenConstraint = func(argument string) (func(string) bool, error) {
+			if argument != "" {
+				return nil, errors.New("even does not accept arguments")
+			}
+
+			return func(value string) bool {
+				return strings.ContainsAny(value[len(value)-1:], "02468")
+			}, nil
+		}
+	)
+
+	tests := []struct {
+		description        string
+		setupRouter        func(*lit.Router)
+		request            *http.Request
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			description: "WhenParameterSatisfiesIntConstraint_ShouldCallHandler",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/users/:id<int>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users/-12", nil),
+			expectedBody:       "id=-12;",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenParameterDoesNotSatisfyIntConstraint_ShouldRespondNotFound",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/users/:id<int>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users/abc", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "WhenParameterSatisfiesUUIDConstraint_ShouldCallHandler",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/orders/:id<uuid>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/orders/19fb2f66-f335-47ef-a1ca-1d02d1a117c8", nil),
+			expectedBody:       "id=19fb2f66-f335-47ef-a1ca-1d02d1a117c8;",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenParameterDoesNotSatisfyUUIDConstraint_ShouldRespondNotFound",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/orders/:id<uuid>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/orders/19fb2f66", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "WhenParameterSatisfiesRegexConstraint_ShouldCallHandler",
+			setupRouter: func(r *lit.Router) {
+				r.GET(`/files/:name<regex:[a-z]+\.txt>`, printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/files/notes.txt", nil),
+			expectedBody:       "name=notes.txt;",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenParameterOnlyPartiallyMatchesRegexConstraint_ShouldRespondNotFound",
+			setupRouter: func(r *lit.Router) {
+				r.GET(`/files/:name<regex:[a-z]+\.txt>`, printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/files/notes.txt.exe", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "WhenRouteHasSeveralConstraints_ShouldCheckAll",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/users/:id<uint>/files/:name<alpha>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users/1/files/notes1", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "WhenCustomConstraintIsRegistered_ShouldUseIt",
+			setupRouter: func(r *lit.Router) {
+				r.RegisterConstraint("even", evenConstraint)
+				r.GET("/numbers/:id<even>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/numbers/13", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "WhenConstraintMismatchHandlerIsSet_ShouldCallIt",
+			setupRouter: func(r *lit.Router) {
+				r.HandleConstraintMismatch(badRequestHandler)
+				r.GET("/users/:id<int>", printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users/abc", nil),
+			expectedBody:       "invalid parameters\n",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			description: "WhenCatchAllHasConstraint_ShouldCheckIt",
+			setupRouter: func(r *lit.Router) {
+				r.GET(`/static/*path<regex:/[a-z/]+>`, printParametersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/static/css/main", nil),
+			expectedBody:       "path=/css/main;",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			test.setupRouter(router)
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, test.request)
+
+			// Assert
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+		})
+	}
+}
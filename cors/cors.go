@@ -0,0 +1,360 @@
+// Package cors contains a [Cross-Origin Resource Sharing] policy for Lit routers, that handles preflight and actual
+// requests as defined by the Fetch standard.
+//
+// A [Policy] is enabled by registering its middleware, that adds the CORS headers to the responses of actual
+// requests, and its preflight handler, that responds the OPTIONS requests sent by browsers before non-simple requests:
+//
+//	policy := cors.New(
+//		cors.WithOrigins("https://example.com", "https://*.example.com"),
+//		cors.WithCredentials(true),
+//	)
+//
+//	r.Use(policy.Middleware)
+//	r.HandleOPTIONS(policy.Preflight)
+//
+// Since [lit.Router] only calls the OPTIONS handler for paths that have registered routes, preflights for unknown
+// routes are responded by the Not Found handler and, therefore, rejected. Likewise, preflights whose requested method
+// is not registered for the path are rejected.
+//
+// [Cross-Origin Resource Sharing]: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
+package cors
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+)
+
+// Option configures a [Policy].
+type Option func(p *Policy)
+
+// WithOrigins allows requests from origins, such as "https://example.com". An origin can contain a wildcard ("*")
+// that matches any sequence of characters, such as "https://*.example.com", and the origin "*" matches any origin.
+// Origins are compared case-insensitively.
+//
+// If no origin is allowed by [WithOrigins], [WithOriginPattern] or [WithOriginFunc], every origin is allowed.
+func WithOrigins(origins ...string) Option {
+	return func(p *Policy) {
+		for _, origin := range origins {
+			origin = strings.ToLower(origin)
+
+			switch {
+			case origin == "*":
+				p.anyOrigin = true
+			case strings.Contains(origin, "*"):
+				prefix, suffix, _ := strings.Cut(origin, "*")
+				p.originMatchers = append(p.originMatchers, func(origin string, _ *lit.Request) bool {
+					return len(origin) >= len(prefix)+len(suffix) &&
+						strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+				})
+			default:
+				p.origins = append(p.origins, origin)
+			}
+		}
+	}
+}
+
+// WithOriginPattern allows requests from origins that match pattern. The origin is lowercased before matching.
+//
+// If pattern is nil, WithOriginPattern panics.
+func WithOriginPattern(pattern *regexp.Regexp) Option {
+	if pattern == nil {
+		panic("pattern should not be nil")
+	}
+
+	return func(p *Policy) {
+		p.originMatchers = append(p.originMatchers, func(origin string, _ *lit.Request) bool {
+			return pattern.MatchString(origin)
+		})
+	}
+}
+
+// WithOriginFunc allows requests for which allowed returns true, given the origin (lowercased) and the request.
+//
+// If allowed is nil, WithOriginFunc panics.
+func WithOriginFunc(allowed func(origin string, r *lit.Request) bool) Option {
+	if allowed == nil {
+		panic("allowed should not be nil")
+	}
+
+	return func(p *Policy) {
+		p.originMatchers = append(p.originMatchers, allowed)
+	}
+}
+
+// WithMethods sets the methods allowed in preflights. By default, every method registered for the path of the
+// request is allowed.
+func WithMethods(methods ...string) Option {
+	return func(p *Policy) {
+		p.methods = methods
+	}
+}
+
+// WithHeaders sets the request headers allowed in preflights. Headers are compared case-insensitively. By default,
+// every requested header is allowed.
+func WithHeaders(headers ...string) Option {
+	return func(p *Policy) {
+		p.headers = make([]string, len(headers))
+		for i, header := range headers {
+			p.headers[i] = strings.ToLower(header)
+		}
+	}
+}
+
+// WithExposedHeaders sets the response headers, other than the [CORS-safelisted] ones, that scripts are allowed to
+// read.
+//
+// [CORS-safelisted]: https://developer.mozilla.org/en-US/docs/Glossary/CORS-safelisted_response_header
+func WithExposedHeaders(headers ...string) Option {
+	return func(p *Policy) {
+		p.exposedHeaders = strings.Join(headers, ", ")
+	}
+}
+
+// WithCredentials sets whether requests can include credentials, such as cookies and authorization headers. By
+// default, they can't.
+//
+// Since any website could then make authenticated requests on behalf of the user, credentials can't be enabled if
+// every origin is allowed (see [New]).
+func WithCredentials(enabled bool) Option {
+	return func(p *Policy) {
+		p.credentials = enabled
+	}
+}
+
+// WithMaxAge sets how long the results of preflights can be cached by browsers. It's rounded down to seconds. By
+// default, the browser default is used.
+//
+// If maxAge is negative, WithMaxAge panics.
+func WithMaxAge(maxAge time.Duration) Option {
+	if maxAge < 0 {
+		panic("maxAge should not be negative")
+	}
+
+	return func(p *Policy) {
+		p.maxAge = strconv.Itoa(int(maxAge.Seconds()))
+	}
+}
+
+// WithPrivateNetwork sets whether preflights can allow requests from public websites to private networks, as defined
+// by [Private Network Access]. By default, they can't.
+//
+// [Private Network Access]: https://wicg.github.io/private-network-access/
+func WithPrivateNetwork(enabled bool) Option {
+	return func(p *Policy) {
+		p.privateNetwork = enabled
+	}
+}
+
+// Policy is a Cross-Origin Resource Sharing policy.
+type Policy struct {
+	anyOrigin      bool
+	origins        []string
+	originMatchers []func(origin string, r *lit.Request) bool
+	methods        []string
+	headers        []string
+	exposedHeaders string
+	credentials    bool
+	maxAge         string
+	privateNetwork bool
+}
+
+// New creates a new [Policy] instance, configured by options.
+//
+// If credentials are enabled and every origin is allowed, either explicitly with "*" or because no origin is
+// configured, New panics.
+func New(options ...Option) *Policy {
+	p := &Policy{}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	if len(p.origins) == 0 && len(p.originMatchers) == 0 {
+		p.anyOrigin = true
+	}
+
+	if p.anyOrigin && p.credentials {
+		panic("credentials should not be allowed for any origin")
+	}
+
+	return p
+}
+
+// Middleware adds the CORS headers to the responses of actual requests whose origin is allowed. Preflights are
+// handled by [*Policy.Preflight] and, therefore, are passed to h unmodified.
+func (p *Policy) Middleware(h lit.Handler) lit.Handler {
+	return func(r *lit.Request) lit.Response {
+		res := h(r)
+
+		if res == nil || isPreflight(r) {
+			return res
+		}
+
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			header := w.Header()
+
+			if p.variesByOrigin() {
+				header.Add("Vary", "Origin")
+			}
+
+			if origin := r.Header().Get("Origin"); origin != "" && p.allowsOrigin(origin, r) {
+				p.setOrigin(header, origin)
+
+				if p.exposedHeaders != "" {
+					header.Set("Access-Control-Expose-Headers", p.exposedHeaders)
+				}
+			}
+
+			res.Write(w)
+		})
+	}
+}
+
+// Preflight responds preflights, allowing them with 204 No Content if the origin, method and headers requested are
+// allowed, or rejecting them with 403 Forbidden, without CORS headers, otherwise. It is meant to be registered with
+// [*lit.Router.HandleOPTIONS], from which it reads the methods registered for the path of the request.
+//
+// OPTIONS requests that are not preflights are responded with 200 OK, as by default in Lit.
+func (p *Policy) Preflight(r *lit.Request) lit.Response {
+	return lit.ResponseFunc(func(w http.ResponseWriter) {
+		if !isPreflight(r) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		header := w.Header()
+
+		header.Add("Vary", "Origin")
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+
+		if p.privateNetwork {
+			header.Add("Vary", "Access-Control-Request-Private-Network")
+		}
+
+		var (
+			origin           = r.Header().Get("Origin")
+			method           = r.Header().Get("Access-Control-Request-Method")
+			requestedHeaders = parseList(r.Header().Values("Access-Control-Request-Headers"))
+			allowedMethods   = parseList(header.Values("Allow"))
+		)
+
+		if !p.allowsOrigin(origin, r) || !p.allowsMethod(method, allowedMethods) || !p.allowsHeaders(requestedHeaders) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		p.setOrigin(header, origin)
+
+		switch {
+		case len(p.methods) > 0:
+			header.Set("Access-Control-Allow-Methods", strings.Join(p.methods, ", "))
+		case len(allowedMethods) > 0:
+			header.Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ", "))
+		default:
+			header.Set("Access-Control-Allow-Methods", method)
+		}
+
+		if len(requestedHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(requestedHeaders, ", "))
+		}
+
+		if p.maxAge != "" {
+			header.Set("Access-Control-Max-Age", p.maxAge)
+		}
+
+		if p.privateNetwork && r.Header().Get("Access-Control-Request-Private-Network") == "true" {
+			header.Set("Access-Control-Allow-Private-Network", "true")
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (p *Policy) allowsOrigin(origin string, r *lit.Request) bool {
+	if p.anyOrigin {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+
+	if slices.Contains(p.origins, origin) {
+		return true
+	}
+
+	for _, matches := range p.originMatchers {
+		if matches(origin, r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsMethod reports whether method is allowed by p and registered for the path (if the registered methods are
+// known).
+func (p *Policy) allowsMethod(method string, registered []string) bool {
+	if len(registered) > 0 && !slices.Contains(registered, method) {
+		return false
+	}
+
+	return len(p.methods) == 0 || slices.Contains(p.methods, method)
+}
+
+func (p *Policy) allowsHeaders(headers []string) bool {
+	if p.headers == nil {
+		return true
+	}
+
+	for _, header := range headers {
+		if !slices.Contains(p.headers, strings.ToLower(header)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Policy) setOrigin(header http.Header, origin string) {
+	if p.anyOrigin {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if p.credentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// variesByOrigin reports whether the CORS headers of the responses depend on the origin of the requests.
+func (p *Policy) variesByOrigin() bool {
+	return !p.anyOrigin
+}
+
+func isPreflight(r *lit.Request) bool {
+	return r.Method() == http.MethodOptions &&
+		r.Header().Get("Origin") != "" &&
+		r.Header().Get("Access-Control-Request-Method") != ""
+}
+
+// parseList parses the comma-separated values of a header.
+func parseList(values []string) []string {
+	var list []string
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
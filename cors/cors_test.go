@@ -0,0 +1,366 @@
+package cors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/cors"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description        string
+		options            []cors.Option
+		method             string
+		path               string
+		header             http.Header
+		expectedStatusCode int
+		expectedHeader     http.Header
+	}{
+		{
+			description:        "WhenRequestHasNoOrigin_ShouldNotSetCORSHeaders",
+			options:            []cors.Option{cors.WithOrigins("https://example.com")},
+			method:             http.MethodGet,
+			path:               "/users",
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type": {"application/json"},
+				"Vary":         {"Origin"},
+			},
+		},
+		{
+			description:        "WhenAnyOriginIsAllowed_ShouldRespondWildcard",
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://example.com"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":                {"application/json"},
+				"Access-Control-Allow-Origin": {"*"},
+			},
+		},
+		{
+			description:        "WhenOriginIsInList_ShouldRespondOrigin",
+			options:            []cors.Option{cors.WithOrigins("https://example.com")},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://EXAMPLE.com"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":                {"application/json"},
+				"Vary":                        {"Origin"},
+				"Access-Control-Allow-Origin": {"https://EXAMPLE.com"},
+			},
+		},
+		{
+			description:        "WhenOriginIsNotAllowed_ShouldNotSetCORSHeaders",
+			options:            []cors.Option{cors.WithOrigins("https://example.com")},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://attacker.com"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type": {"application/json"},
+				"Vary":         {"Origin"},
+			},
+		},
+		{
+			description:        "WhenOriginMatchesWildcard_ShouldRespondOrigin",
+			options:            []cors.Option{cors.WithOrigins("https://*.example.com")},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://api.example.com"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":                {"application/json"},
+				"Vary":                        {"Origin"},
+				"Access-Control-Allow-Origin": {"https://api.example.com"},
+			},
+		},
+		{
+			description:        "WhenOriginDoesNotMatchWildcard_ShouldNotSetCORSHeaders",
+			options:            []cors.Option{cors.WithOrigins("https://*.example.com")},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://example.org"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type": {"application/json"},
+				"Vary":         {"Origin"},
+			},
+		},
+		{
+			description: "WhenOriginMatchesPattern_ShouldRespondOrigin",
+			options: []cors.Option{
+				cors.WithOriginPattern(regexp.MustCompile(`^http://localhost:\d+$`)),
+			},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"http://localhost:3000"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":                {"application/json"},
+				"Vary":                        {"Origin"},
+				"Access-Control-Allow-Origin": {"http://localhost:3000"},
+			},
+		},
+		{
+			description: "WhenOriginFuncAllowsOrigin_ShouldRespondOrigin",
+			options: []cors.Option{
+				cors.WithOriginFunc(func(origin string, r *lit.Request) bool {
+					return strings.HasSuffix(origin, ".test")
+				}),
+			},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://app.test"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":                {"application/json"},
+				"Vary":                        {"Origin"},
+				"Access-Control-Allow-Origin": {"https://app.test"},
+			},
+		},
+		{
+			description: "WhenCredentialsAndExposedHeadersAreSet_ShouldRespondThem",
+			options: []cors.Option{
+				cors.WithOrigins("https://example.com"),
+				cors.WithCredentials(true),
+				cors.WithExposedHeaders("X-Total-Count", "X-Request-Id"),
+			},
+			method:             http.MethodGet,
+			path:               "/users",
+			header:             http.Header{"Origin": {"https://example.com"}},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":                     {"application/json"},
+				"Vary":                             {"Origin"},
+				"Access-Control-Allow-Origin":      {"https://example.com"},
+				"Access-Control-Allow-Credentials": {"true"},
+				"Access-Control-Expose-Headers":    {"X-Total-Count, X-Request-Id"},
+			},
+		},
+		{
+			description: "WhenPreflightIsAllowed_ShouldRespondNoContent",
+			options: []cors.Option{
+				cors.WithOrigins("https://example.com"),
+				cors.WithMaxAge(10 * time.Minute),
+			},
+			method: http.MethodOptions,
+			path:   "/users",
+			header: http.Header{
+				"Origin":                         {"https://example.com"},
+				"Access-Control-Request-Method":  {"POST"},
+				"Access-Control-Request-Headers": {"content-type, x-api-key"},
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedHeader: http.Header{
+				"Allow":                        {"GET, OPTIONS, POST"},
+				"Vary":                         {"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+				"Access-Control-Allow-Origin":  {"https://example.com"},
+				"Access-Control-Allow-Methods": {"GET, OPTIONS, POST"},
+				"Access-Control-Allow-Headers": {"content-type, x-api-key"},
+				"Access-Control-Max-Age":       {"600"},
+			},
+		},
+		{
+			description: "WhenPreflightRequestsPrivateNetwork_ShouldAllowIt",
+			options: []cors.Option{
+				cors.WithMethods(http.MethodPost),
+				cors.WithPrivateNetwork(true),
+			},
+			method: http.MethodOptions,
+			path:   "/users",
+			header: http.Header{
+				"Origin":                                 {"https://example.com"},
+				"Access-Control-Request-Method":          {"POST"},
+				"Access-Control-Request-Private-Network": {"true"},
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS, POST"},
+				"Vary": {
+					"Origin",
+					"Access-Control-Request-Method",
+					"Access-Control-Request-Headers",
+					"Access-Control-Request-Private-Network",
+				},
+				"Access-Control-Allow-Origin":          {"*"},
+				"Access-Control-Allow-Methods":         {"POST"},
+				"Access-Control-Allow-Private-Network": {"true"},
+			},
+		},
+		{
+			description: "WhenPreflightOriginIsNotAllowed_ShouldRespondForbidden",
+			options:     []cors.Option{cors.WithOrigins("https://example.com")},
+			method:      http.MethodOptions,
+			path:        "/users",
+			header: http.Header{
+				"Origin":                        {"https://attacker.com"},
+				"Access-Control-Request-Method": {"POST"},
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS, POST"},
+				"Vary":  {"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+			},
+		},
+		{
+			description: "WhenPreflightMethodIsNotRegistered_ShouldRespondForbidden",
+			method:      http.MethodOptions,
+			path:        "/users",
+			header: http.Header{
+				"Origin":                        {"https://example.com"},
+				"Access-Control-Request-Method": {"DELETE"},
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS, POST"},
+				"Vary":  {"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+			},
+		},
+		{
+			description: "WhenPreflightMethodIsNotAllowed_ShouldRespondForbidden",
+			options:     []cors.Option{cors.WithMethods(http.MethodGet)},
+			method:      http.MethodOptions,
+			path:        "/users",
+			header: http.Header{
+				"Origin":                        {"https://example.com"},
+				"Access-Control-Request-Method": {"POST"},
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS, POST"},
+				"Vary":  {"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+			},
+		},
+		{
+			description: "WhenPreflightHeaderIsNotAllowed_ShouldRespondForbidden",
+			options:     []cors.Option{cors.WithHeaders("Content-Type")},
+			method:      http.MethodOptions,
+			path:        "/users",
+			header: http.Header{
+				"Origin":                         {"https://example.com"},
+				"Access-Control-Request-Method":  {"POST"},
+				"Access-Control-Request-Headers": {"content-type, x-api-key"},
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS, POST"},
+				"Vary":  {"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
+			},
+		},
+		{
+			description: "WhenPreflightRouteIsUnknown_ShouldRespondNotFound",
+			method:      http.MethodOptions,
+			path:        "/books",
+			header: http.Header{
+				"Origin":                        {"https://example.com"},
+				"Access-Control-Request-Method": {"GET"},
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedHeader: http.Header{
+				"Content-Type":           {"text/plain; charset=utf-8"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+		},
+		{
+			description:        "WhenOPTIONSRequestIsNotPreflight_ShouldRespondOK",
+			method:             http.MethodOptions,
+			path:               "/users",
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     http.Header{"Allow": {"GET, OPTIONS, POST"}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			policy := cors.New(test.options...)
+
+			router := lit.NewRouter()
+			router.Use(policy.Middleware)
+			router.HandleOPTIONS(policy.Preflight)
+
+			router.GET("/users", func(r *lit.Request) lit.Response {
+				return render.OK([]string{"John"})
+			})
+			router.POST("/users", func(r *lit.Request) lit.Response {
+				return render.Created(nil, "/users/1")
+			})
+
+			request := httptest.NewRequest(test.method, test.path, nil)
+			for key, values := range test.header {
+				request.Header[key] = values
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedHeader, recorder.Header())
+		})
+	}
+}
+
+func TestOptions_WhenArgumentsAreInvalid_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description   string
+		function      func()
+		expectedPanic string
+	}{
+		{
+			description:   "NilOriginPattern",
+			function:      func() { cors.WithOriginPattern(nil) },
+			expectedPanic: "pattern should not be nil",
+		},
+		{
+			description:   "NilOriginFunc",
+			function:      func() { cors.WithOriginFunc(nil) },
+			expectedPanic: "allowed should not be nil",
+		},
+		{
+			description:   "CredentialsForImplicitAnyOrigin",
+			function:      func() { cors.New(cors.WithCredentials(true)) },
+			expectedPanic: "credentials should not be allowed for any origin",
+		},
+		{
+			description: "CredentialsForWildcardOrigin",
+			function: func() {
+				cors.New(cors.WithOrigins("https://example.com", "*"), cors.WithCredentials(true))
+			},
+			expectedPanic: "credentials should not be allowed for any origin",
+		},
+		{
+			description:   "NegativeMaxAge",
+			function:      func() { cors.WithMaxAge(-time.Second) },
+			expectedPanic: "maxAge should not be negative",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.expectedPanic, test.function)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package cors_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/cors"
+	"github.com/jvcoutinho/lit/render"
+)
+
+func Example() {
+	policy := cors.New(
+		cors.WithOrigins("https://*.example.com"),
+		cors.WithCredentials(true),
+	)
+
+	r := lit.NewRouter()
+	r.Use(policy.Middleware)
+	r.HandleOPTIONS(policy.Preflight)
+
+	r.PATCH("/users/:id", func(r *lit.Request) lit.Response {
+		return render.NoContent()
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/users/1", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "PATCH")
+
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	fmt.Println(res.Code)
+	fmt.Println(res.Header().Get("Access-Control-Allow-Origin"))
+	fmt.Println(res.Header().Get("Access-Control-Allow-Methods"))
+
+	// Output:
+	// 204
+	// https://app.example.com
+	// OPTIONS, PATCH
+}
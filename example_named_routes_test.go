@@ -0,0 +1,47 @@
+package lit_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+)
+
+// CreateBook creates a new book, responding with its location.
+func CreateBook(router *lit.Router) lit.Handler {
+	return func(r *lit.Request) lit.Response {
+		// creating book...
+		bookID := "42"
+
+		location, err := router.URL("get_book", map[string]string{"book_id": bookID}, nil)
+		if err != nil {
+			return render.InternalServerError(err)
+		}
+
+		return render.Created(nil, location)
+	}
+}
+
+// GetBook gets an identified book.
+func GetBook(r *lit.Request) lit.Response {
+	return render.OK(r.URIParameters()["book_id"])
+}
+
+func Example_namedRoutes() {
+	r := lit.NewRouter()
+
+	r.GET("/books/:book_id", GetBook).WithName("get_book")
+	r.POST("/books", CreateBook(r))
+
+	req := httptest.NewRequest(http.MethodPost, "/books", nil)
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	fmt.Println(res.Header().Get("Location"), res.Code)
+
+	// Output:
+	// /books/42 201
+}
@@ -0,0 +1,66 @@
+package lit_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+)
+
+// RequireScopes is a global middleware that checks if the request carries the scopes declared in the matched route.
+func RequireScopes(h lit.Handler) lit.Handler {
+	return func(r *lit.Request) lit.Response {
+		required, _ := r.Route().Metadata["scopes"].([]string)
+		granted := strings.Fields(r.Header().Get("X-Scopes"))
+
+		for _, scope := range required {
+			if !slices.Contains(granted, scope) {
+				return render.Forbidden("missing scope " + scope)
+			}
+		}
+
+		return h(r)
+	}
+}
+
+// CreateOrder creates a new order.
+func CreateOrder(_ *lit.Request) lit.Response {
+	return render.Created(nil, "/orders/1")
+}
+
+// ListOrders lists the orders.
+func ListOrders(_ *lit.Request) lit.Response {
+	return render.OK([]string{})
+}
+
+func Example_routeMetadata() {
+	r := lit.NewRouter()
+	r.Use(RequireScopes)
+
+	r.GET("/orders", ListOrders)
+	r.POST("/orders", CreateOrder).WithMetadata("scopes", []string{"orders:write"})
+
+	requestWithScopes(r, http.MethodGet, "/orders", "")
+	requestWithScopes(r, http.MethodPost, "/orders", "orders:read")
+	requestWithScopes(r, http.MethodPost, "/orders", "orders:read orders:write")
+
+	// Output:
+	// 200 []
+	// 403 {"message":"missing scope orders:write"}
+	// 201
+}
+
+func requestWithScopes(r *lit.Router, method, path, scopes string) {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-Scopes", scopes)
+
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	fmt.Println(res.Code, res.Body)
+}
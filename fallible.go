@@ -0,0 +1,125 @@
+package lit
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+// FallibleHandler handles requests, returning an error if it can't respond them. Errors are translated into
+// responses by the error handler of the [Router] (see [*Router.HandleError]), so that this logic is not repeated in
+// every handler.
+//
+// Register a FallibleHandler with [*Router.HandleFallible] or [*Group.HandleFallible], or by converting it with
+// [*Router.Fallible].
+type FallibleHandler func(r *Request) (Response, error)
+
+// StatusCoder is implemented by errors that determine the status code of the responses to the requests that
+// caused them, such as [github.com/jvcoutinho/lit/bind.Error] (400 Bad Request) and
+// [github.com/jvcoutinho/lit/validate.Error] (422 Unprocessable Content).
+type StatusCoder interface {
+	// StatusCode of the response.
+	StatusCode() int
+}
+
+// Fallible converts handler into a [Handler] that responds the errors returned by handler with the error handler of
+// r. For example:
+//
+//	r.GET("/users/:id", r.Fallible(GetUser))
+//
+// Since the converted handler is a closure, the routes registered with it are described with the name of the closure
+// (see [RouteInfo]). Prefer [*Router.HandleFallible] to keep the name of handler.
+//
+// If handler is nil, Fallible panics.
+func (r *Router) Fallible(handler FallibleHandler) Handler {
+	if handler == nil {
+		panic("handler should not be nil")
+	}
+
+	return func(req *Request) Response {
+		response, err := handler(req)
+		if err != nil {
+			return r.errorHandler(req, err)
+		}
+
+		return response
+	}
+}
+
+// HandleFallible registers handler, converted with [*Router.Fallible], for path and method and optional local
+// middlewares. The registered route is described with the name of handler.
+//
+// Check [*Router.Handle] for more details.
+func (r *Router) HandleFallible(path string, method string, handler FallibleHandler, middlewares ...Middleware) *Route {
+	route := r.Handle(path, method, r.Fallible(handler), middlewares...)
+	route.handlerName = handlerName(handler)
+
+	return route
+}
+
+// HandleFallible registers handler, converted with [*Router.Fallible], for the group prefix followed by path, method
+// and optional local middlewares. The registered route is described with the name of handler.
+//
+// Check [*Group.Handle] for more details.
+func (g *Group) HandleFallible(path string, method string, handler FallibleHandler, middlewares ...Middleware) *Route {
+	route := g.Handle(path, method, g.router.Fallible(handler), middlewares...)
+	route.handlerName = handlerName(handler)
+
+	return route
+}
+
+// HandleError registers handler to translate the errors returned by handlers converted with [*Router.Fallible]
+// into responses.
+//
+// By default, Lit responds the error message as JSON, such as {"message":"user not found"}, with the status code of
+// the first error in the chain that implements [StatusCoder], such as [github.com/jvcoutinho/lit/bind.Error] (400 Bad
+// Request), [github.com/jvcoutinho/lit/validate.Error] (422 Unprocessable Content) and
+// [github.com/jvcoutinho/lit/bind.ErrUnsupportedContentType] (415 Unsupported Media Type). Other errors are logged
+// and responded with 500 Internal Server Error, without exposing their messages. This is the same format used by
+// [github.com/jvcoutinho/lit/typed] handlers (see [ErrorResponse]).
+//
+// If handler is nil, HandleError restores the default behaviour.
+//
+// If r has already started serving requests, HandleError panics.
+func (r *Router) HandleError(handler func(r *Request, err error) Response) {
+	r.checkNotFrozen()
+
+	if handler == nil {
+		handler = ErrorResponse
+	}
+
+	r.errorHandler = handler
+}
+
+// ErrorResponse translates err, returned while handling r, into the response of the default error handler of
+// [Router] (see [*Router.HandleError]). The error message is responded as JSON, such as {"message":"user not found"},
+// with the status code of the first error in the chain that implements [StatusCoder]. Errors whose status code is
+// 500 or greater, including the ones that don't implement [StatusCoder], are logged and responded without exposing
+// their messages.
+func ErrorResponse(r *Request, err error) Response {
+	statusCode := http.StatusInternalServerError
+
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		statusCode = coder.StatusCode()
+	}
+
+	message := err.Error()
+
+	if statusCode >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", r.Method(), r.URL(), err)
+
+		message = http.StatusText(statusCode)
+	}
+
+	return ResponseFunc(func(w http.ResponseWriter) {
+		body, _ := json.Marshal(struct {
+			Message string `json:"message"`
+		}{message})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(body)
+	})
+}
@@ -0,0 +1,197 @@
+package lit_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/bind"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/jvcoutinho/lit/validate"
+	"github.com/stretchr/testify/require"
+)
+
+type conflictError struct{}
+
+func (e conflictError) Error() string {
+	return "user already exists"
+}
+
+func (e conflictError) StatusCode() int {
+	return http.StatusConflict
+}
+
+func TestRouter_Fallible(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description        string
+		errorHandler       func(r *lit.Request, err error) lit.Response
+		err                error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description:        "WhenHandlerSucceeds_ShouldRespondItsResponse",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"message":"ok"}`,
+		},
+		{
+			description:        "WhenErrorIsBindError_ShouldRespondBadRequest",
+			err:                fmt.Errorf("id: %w", bind.Error{Value: "one", Target: reflect.TypeOf(0)}),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"id: one is not a valid int"}`,
+		},
+		{
+			description:        "WhenErrorIsValidateError_ShouldRespondUnprocessableEntity",
+			err:                validate.Error{Violations: []validate.Field{{Message: "name is required"}}},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"message":"name is required"}`,
+		},
+		{
+			description:        "WhenErrorIsUnsupportedContentType_ShouldRespondUnsupportedMediaType",
+			err:                bind.ErrUnsupportedContentType,
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedBody:       `{"message":"unsupported Content-Type"}`,
+		},
+		{
+			description:        "WhenErrorImplementsStatusCoder_ShouldRespondItsStatusCode",
+			err:                fmt.Errorf("creating user: %w", conflictError{}),
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"message":"creating user: user already exists"}`,
+		},
+		{
+			description:        "WhenErrorIsUnknown_ShouldRespondInternalServerErrorWithoutMessage",
+			err:                errors.New("connection refused"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"message":"Internal Server Error"}`,
+		},
+		{
+			description: "WhenErrorHandlerIsRegistered_ShouldUseIt",
+			errorHandler: func(r *lit.Request, err error) lit.Response {
+				return render.JSON(http.StatusServiceUnavailable, err)
+			},
+			err:                errors.New("connection refused"),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       `{"message":"connection refused"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+
+			if test.errorHandler != nil {
+				router.HandleError(test.errorHandler)
+			}
+
+			router.POST("/users", router.Fallible(func(r *lit.Request) (lit.Response, error) {
+				if test.err != nil {
+					return nil, test.err
+				}
+
+				return render.OK("ok"), nil
+			}))
+
+			request := httptest.NewRequest(http.MethodPost, "/users", nil)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestRouter_Fallible_WhenHandlerIsNil_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	router := lit.NewRouter()
+
+	require.PanicsWithValue(t, "handler should not be nil", func() {
+		router.Fallible(nil)
+	})
+}
+
+func TestRouter_HandleError_WhenHandlerIsNil_ShouldRestoreDefault(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	router := lit.NewRouter()
+	router.HandleError(func(r *lit.Request, err error) lit.Response {
+		return render.NoContent()
+	})
+	router.HandleError(nil)
+
+	router.GET("/users", router.Fallible(func(r *lit.Request) (lit.Response, error) {
+		return nil, conflictError{}
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/users", nil)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func createUser(r *lit.Request) (lit.Response, error) {
+	return nil, conflictError{}
+}
+
+func TestRouter_HandleFallible(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		register    func(router *lit.Router) *lit.Route
+	}{
+		{
+			description: "Router",
+			register: func(router *lit.Router) *lit.Route {
+				return router.HandleFallible("/users", http.MethodPost, createUser)
+			},
+		},
+		{
+			description: "Group",
+			register: func(router *lit.Router) *lit.Route {
+				return router.Group("/users").HandleFallible("/", http.MethodPost, createUser)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter(lit.WithRedirectTrailingSlash(false))
+			route := test.register(router)
+
+			request := httptest.NewRequest(http.MethodPost, "/users", nil)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, "github.com/jvcoutinho/lit_test.createUser", route.Info().Handler)
+			require.Equal(t, http.StatusConflict, recorder.Code)
+			require.Equal(t, `{"message":"user already exists"}`, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package lit
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Group registers handlers under a common path prefix and with common middlewares.
+//
+// Create new groups using the [*Router.Group], [*Router.Host] or [*Group.Group] methods.
+type Group struct {
+	router      *Router
+	host        *host
+	prefix      string
+	middlewares []Middleware
+}
+
+// Group creates a new [Group] of routes whose paths start with prefix and whose handlers are transformed by
+// middlewares.
+//
+// Middlewares are applied in reverse order. Global middlewares are always applied last, followed by the group
+// middlewares (from the outermost group to the innermost one) and by local middlewares. For example, suppose there
+// have been defined global middlewares G1 and G2 in this order, a group with middlewares Group1, a nested group with
+// middlewares Group2 and local middlewares L1 and L2 in this order. The response for the request r handled by h is
+//
+//	(G1(G2(Group1(Group2(L1(L2(h)))))))(r)
+//
+// If prefix does not contain a leading slash or a middleware is nil, Group panics.
+func (r *Router) Group(prefix string, middlewares ...Middleware) *Group {
+	return newGroup(r, nil, "", nil).Group(prefix, middlewares...)
+}
+
+func newGroup(router *Router, host *host, prefix string, middlewares []Middleware) *Group {
+	return &Group{router, host, prefix, middlewares}
+}
+
+// Group creates a new [Group] nested in g. Its prefix is appended to the prefix of g and its middlewares are applied
+// after the middlewares of g.
+//
+// If prefix does not contain a leading slash or a middleware is nil, Group panics.
+func (g *Group) Group(prefix string, middlewares ...Middleware) *Group {
+	if !strings.HasPrefix(prefix, "/") {
+		panic("prefix should begin with '/'")
+	}
+
+	if slices.ContainsFunc(middlewares, func(m Middleware) bool { return m == nil }) {
+		panic("middlewares should not be nil")
+	}
+
+	return newGroup(
+		g.router,
+		g.host,
+		g.prefix+strings.TrimSuffix(prefix, "/"),
+		append(slices.Clone(g.middlewares), middlewares...),
+	)
+}
+
+// Handle registers handler for the group prefix followed by path, method and optional local middlewares.
+//
+// Handle returns the registered [*Route], that can be further configured.
+//
+// If path does not contain a leading slash, method is empty, handler is nil or a middleware is nil, Handle panics.
+func (g *Group) Handle(path string, method string, handler Handler, middlewares ...Middleware) *Route {
+	if !strings.HasPrefix(path, "/") {
+		panic("path must begin with '/' in path '" + path + "'")
+	}
+
+	return g.router.handle(g.host, g.prefix+path, method, handler, append(slices.Clone(g.middlewares), middlewares...))
+}
+
+// GET registers handler for the group prefix followed by path, GET method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "GET", handler, middlewares)
+func (g *Group) GET(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodGet, handler, middlewares...)
+}
+
+// POST registers handler for the group prefix followed by path, POST method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "POST", handler, middlewares)
+func (g *Group) POST(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodPost, handler, middlewares...)
+}
+
+// PUT registers handler for the group prefix followed by path, PUT method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "PUT", handler, middlewares)
+func (g *Group) PUT(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodPut, handler, middlewares...)
+}
+
+// PATCH registers handler for the group prefix followed by path, PATCH method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "PATCH", handler, middlewares)
+func (g *Group) PATCH(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodPatch, handler, middlewares...)
+}
+
+// DELETE registers handler for the group prefix followed by path, DELETE method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "DELETE", handler, middlewares)
+func (g *Group) DELETE(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodDelete, handler, middlewares...)
+}
+
+// OPTIONS registers handler for the group prefix followed by path, OPTIONS method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "OPTIONS", handler, middlewares)
+func (g *Group) OPTIONS(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodOptions, handler, middlewares...)
+}
+
+// HEAD registers handler for the group prefix followed by path, HEAD method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "HEAD", handler, middlewares)
+func (g *Group) HEAD(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, http.MethodHead, handler, middlewares...)
+}
+
+// QUERY registers handler for the group prefix followed by path, QUERY method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "QUERY", handler, middlewares)
+func (g *Group) QUERY(path string, handler Handler, middlewares ...Middleware) *Route {
+	return g.Handle(path, MethodQuery, handler, middlewares...)
+}
@@ -0,0 +1,253 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_Group(t *testing.T) {
+	t.Parallel()
+
+	middleware := func(h lit.Handler) lit.Handler { return h }
+
+	tests := []struct {
+		description string
+		prefix      string
+		middlewares []lit.Middleware
+		panicValue  string
+	}{
+		{
+			description: "WhenPrefixDoesNotContainALeadingSlash_ShouldPanic",
+			prefix:      "api",
+			panicValue:  "prefix should begin with '/'",
+		},
+		{
+			description: "WhenMiddlewaresContainsANilElement_ShouldPanic",
+			prefix:      "/api",
+			middlewares: []lit.Middleware{nil, middleware},
+			panicValue:  "middlewares should not be nil",
+		},
+		{
+			description: "WhenPrefixAndMiddlewaresAreValid_ShouldNotPanic",
+			prefix:      "/api",
+			middlewares: []lit.Middleware{middleware},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+
+			// Act
+			// Assert
+			if test.panicValue != "" {
+				require.PanicsWithValue(t, test.panicValue, func() {
+					router.Group(test.prefix, test.middlewares...)
+				})
+
+				return
+			}
+
+			require.NotPanics(t, func() {
+				router.Group(test.prefix, test.middlewares...)
+			})
+		})
+	}
+}
+
+func TestGroup_Handle(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response { return nil }
+
+	tests := []struct {
+		description string
+		path        string
+		method      string
+		handler     lit.Handler
+		middlewares []lit.Middleware
+		panicValue  string
+	}{
+		{
+			description: "WhenPathDoesNotContainALeadingSlash_ShouldPanic",
+			path:        "users",
+			method:      http.MethodGet,
+			handler:     handler,
+			panicValue:  `path must begin with '/' in path 'users'`,
+		},
+		{
+			description: "WhenHandlerIsNil_ShouldPanic",
+			path:        "/users",
+			method:      http.MethodGet,
+			handler:     nil,
+			panicValue:  "handler should not be nil",
+		},
+		{
+			description: "WhenMethodIsEmpty_ShouldPanic",
+			path:        "/users",
+			method:      "",
+			handler:     handler,
+			panicValue:  "method should not be empty",
+		},
+		{
+			description: "WhenMiddlewaresContainsANilElement_ShouldPanic",
+			path:        "/users",
+			method:      http.MethodGet,
+			handler:     handler,
+			middlewares: []lit.Middleware{nil},
+			panicValue:  "middlewares should not be nil",
+		},
+		{
+			description: "ShouldRegisterHandlerWithPrefix",
+			path:        "/users",
+			method:      http.MethodGet,
+			handler:     handler,
+			panicValue:  `a handle is already registered for path '/api/users'`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			group := lit.NewRouter().Group("/api")
+
+			// Act
+			// Assert
+			require.PanicsWithValue(t, test.panicValue, func() {
+				group.Handle(test.path, test.method, test.handler, test.middlewares...)
+
+				// Attempting to handle again in order to deliberately create a panic. This will only happen
+				// if the handler is successfully registered.
+				group.Handle(test.path, test.method, test.handler, test.middlewares...)
+			})
+		})
+	}
+}
+
+func TestGroup_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	var (
+		printPathHandler = func(r *lit.Request) lit.Response {
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Write([]byte(r.URL().Path))
+			})
+		}
+
+		writeMiddleware = func(name string) lit.Middleware {
+			return func(h lit.Handler) lit.Handler {
+				return func(r *lit.Request) lit.Response {
+					res := h(r)
+
+					return lit.ResponseFunc(func(w http.ResponseWriter) {
+						w.Write([]byte(name + "("))
+						res.Write(w)
+						w.Write([]byte(")"))
+					})
+				}
+			}
+		}
+	)
+
+	tests := []struct {
+		description        string
+		setupRouter        func(*lit.Router)
+		request            *http.Request
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			description: "ShouldPrefixPath",
+			setupRouter: func(r *lit.Router) {
+				r.Group("/api").GET("/users", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/users", nil),
+			expectedBody:       "/api/users",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenPrefixHasTrailingSlash_ShouldNotDuplicateSlashes",
+			setupRouter: func(r *lit.Router) {
+				r.Group("/api/").GET("/users", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/users", nil),
+			expectedBody:       "/api/users",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenGroupsAreNested_ShouldConcatenatePrefixes",
+			setupRouter: func(r *lit.Router) {
+				r.Group("/api").Group("/v1").Group("/users").GET("/:id", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil),
+			expectedBody:       "/api/v1/users/1",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenPathIsNotPrefixed_ShouldRespondNotFound",
+			setupRouter: func(r *lit.Router) {
+				r.Group("/api").GET("/users", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "ShouldApplyMiddlewaresInOrder",
+			setupRouter: func(r *lit.Router) {
+				r.Use(writeMiddleware("G1"))
+				r.Use(writeMiddleware("G2"))
+
+				api := r.Group("/api", writeMiddleware("Group1"))
+				v1 := api.Group("/v1", writeMiddleware("Group2"))
+
+				v1.GET("/users", printPathHandler, writeMiddleware("L1"), writeMiddleware("L2"))
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/v1/users", nil),
+			expectedBody:       "G1(G2(Group1(Group2(L1(L2(/api/v1/users))))))",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenSiblingGroupsAreCreated_ShouldNotShareMiddlewares",
+			setupRouter: func(r *lit.Router) {
+				api := r.Group("/api", writeMiddleware("API"))
+				api.Group("/v1", writeMiddleware("V1")).GET("/users", printPathHandler)
+				api.Group("/v2", writeMiddleware("V2")).GET("/users", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/v2/users", nil),
+			expectedBody:       "API(V2(/api/v2/users))",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			test.setupRouter(router)
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, test.request)
+
+			// Assert
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package lit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// headKey identifies the HEAD route of a path.
+type headKey struct {
+	host *host
+	path string
+}
+
+// headRoute is the HEAD route of a path when HEAD requests are answered automatically. The HEAD route derived from a
+// GET route is registered along with it, so that conflicts are detected at registration, and is built when the
+// router is frozen, unless a HEAD route is registered explicitly for the path later.
+type headRoute struct {
+	get    *Route
+	route  *Route
+	handle httprouter.Handle
+}
+
+// handleHEAD registers route, whose method is GET or HEAD, in the tree of its host, along with the HEAD route derived
+// from it if it's a GET route.
+func (r *Router) handleHEAD(route *Route) {
+	tree := r.tree(route.host)
+	key := headKey{route.host, route.path}
+	head, ok := r.heads[key]
+
+	if route.method == http.MethodGet {
+		r.register(tree, http.MethodGet, route.path, r.dispatch(route))
+
+		if ok {
+			return
+		}
+
+		head = &headRoute{get: route, route: &Route{}}
+
+		r.register(tree, http.MethodHead, route.path, func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+			head.handle(w, req, params)
+		})
+
+		r.heads[key] = head
+
+		return
+	}
+
+	if !ok || head.get == nil {
+		r.register(tree, http.MethodHead, route.path, r.dispatch(route))
+		r.heads[key] = &headRoute{route: route}
+
+		return
+	}
+
+	head.get = nil
+	head.route = route
+}
+
+// freezeHEAD builds the HEAD routes derived from GET routes.
+func (r *Router) freezeHEAD() {
+	for _, route := range r.routes {
+		head, ok := r.heads[headKey{route.host, route.path}]
+		if !ok || head.get != route {
+			continue
+		}
+
+		*head.route = *route
+		head.route.method = http.MethodHead
+		head.route.info.Method = http.MethodHead
+		head.route.chain = discardBody(route.chain)
+	}
+
+	for _, head := range r.heads {
+		head.handle = r.dispatch(head.route)
+	}
+}
+
+// discardBody transforms h so that its response body is discarded, keeping the headers and status code.
+func discardBody(h Handler) Handler {
+	return func(r *Request) Response {
+		response := h(r)
+		if response == nil {
+			return nil
+		}
+
+		return ResponseFunc(func(w http.ResponseWriter) {
+			writer := &headWriter{ResponseWriter: w}
+			response.Write(writer)
+			writer.commit(true)
+		})
+	}
+}
+
+// headWriter is a http.ResponseWriter that discards the body, counting its size to report it in the Content-Length
+// header.
+type headWriter struct {
+	http.ResponseWriter
+	statusCode    int
+	contentLength int
+	committed     bool
+}
+
+func (w *headWriter) WriteHeader(statusCode int) {
+	if statusCode >= 100 && statusCode <= 199 {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *headWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	w.contentLength += len(b)
+
+	return len(b), nil
+}
+
+// Flush sends the headers, since the length of the body can't be known anymore.
+func (w *headWriter) Flush() {
+	w.commit(false)
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *headWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *headWriter) commit(reportLength bool) {
+	if w.committed {
+		return
+	}
+
+	w.committed = true
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+
+	bodyAllowed := w.statusCode != http.StatusNoContent && w.statusCode != http.StatusNotModified
+	if reportLength && bodyAllowed && w.Header().Get("Content-Length") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(w.contentLength))
+	}
+
+	w.ResponseWriter.WriteHeader(w.statusCode)
+}
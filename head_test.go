@@ -0,0 +1,160 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_ServeHTTP_AutomaticHEAD(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description           string
+		options               []lit.RouterOption
+		host                  string
+		path                  string
+		expectedStatusCode    int
+		expectedHeader        http.Header
+		expectedContentLength string
+		expectedBody          string
+	}{
+		{
+			description:        "WhenOptionIsDisabled_ShouldRespondMethodNotAllowed",
+			path:               "/users",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedBody:       "Method Not Allowed\n",
+		},
+		{
+			description:           "WhenGETRouteIsRegistered_ShouldRespondHeadersAndContentLengthOnly",
+			options:               []lit.RouterOption{lit.WithAutomaticHEAD(true)},
+			path:                  "/users",
+			expectedStatusCode:    http.StatusOK,
+			expectedHeader:        http.Header{"Content-Type": {"application/json"}},
+			expectedContentLength: "15",
+		},
+		{
+			description:           "WhenGETHandlerRespondsWithStatusCode_ShouldKeepIt",
+			options:               []lit.RouterOption{lit.WithAutomaticHEAD(true)},
+			path:                  "/users/0",
+			expectedStatusCode:    http.StatusNotFound,
+			expectedHeader:        http.Header{"Content-Type": {"application/json"}},
+			expectedContentLength: "28",
+		},
+		{
+			description:        "WhenHEADRouteIsRegistered_ShouldUseIt",
+			options:            []lit.RouterOption{lit.WithAutomaticHEAD(true)},
+			path:               "/books",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			description:           "WhenGETRouteIsRegisteredInHost_ShouldRespondHeadersAndContentLengthOnly",
+			options:               []lit.RouterOption{lit.WithAutomaticHEAD(true)},
+			host:                  "admin.example.com",
+			path:                  "/users",
+			expectedStatusCode:    http.StatusOK,
+			expectedHeader:        http.Header{"Content-Type": {"application/json"}},
+			expectedContentLength: "19",
+		},
+		{
+			description:        "WhenNoGETRouteIsRegistered_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithAutomaticHEAD(true)},
+			path:               "/orders",
+			expectedStatusCode: http.StatusNotFound,
+			expectedHeader: http.Header{
+				"Content-Type":           {"text/plain; charset=utf-8"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+			expectedBody: "404 page not found\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter(test.options...)
+
+			router.GET("/users", func(r *lit.Request) lit.Response {
+				return render.OK([]string{"John", "Jane"})
+			})
+			router.GET("/users/:id", func(r *lit.Request) lit.Response {
+				return render.NotFound("user not found")
+			})
+			router.GET("/books", func(r *lit.Request) lit.Response {
+				return render.OK("books")
+			})
+			router.HEAD("/books", func(r *lit.Request) lit.Response {
+				return render.NoContent()
+			})
+			router.Host("admin.example.com").GET("/users", func(r *lit.Request) lit.Response {
+				return render.OK("admin")
+			})
+
+			request := httptest.NewRequest(http.MethodHead, test.path, nil)
+			if test.host != "" {
+				request.Host = test.host
+			}
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedContentLength, recorder.Header().Get("Content-Length"))
+
+			for key := range test.expectedHeader {
+				require.Equal(t, test.expectedHeader.Get(key), recorder.Header().Get(key))
+			}
+		})
+	}
+}
+
+func TestRouter_Handle_WhenAutomaticHEADConflicts_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	router := lit.NewRouter(lit.WithAutomaticHEAD(true))
+	router.GET("/users/:id", func(r *lit.Request) lit.Response {
+		return render.OK("user")
+	})
+
+	// Act
+	// Assert
+	require.Panics(t, func() {
+		router.HEAD("/users/:name/books", func(r *lit.Request) lit.Response {
+			return render.NoContent()
+		})
+	})
+}
+
+func TestRouter_ServeHTTP_WhenHEADRouteIsRegisteredBeforeGETRoute_ShouldUseIt(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	router := lit.NewRouter(lit.WithAutomaticHEAD(true))
+	router.HEAD("/books", func(r *lit.Request) lit.Response {
+		return render.NoContent()
+	})
+	router.GET("/books", func(r *lit.Request) lit.Response {
+		return render.OK("books")
+	})
+
+	request := httptest.NewRequest(http.MethodHead, "/books", nil)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
@@ -0,0 +1,149 @@
+package lit
+
+import (
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// host is a set of routes that only match requests whose Host header matches a pattern.
+type host struct {
+	pattern string
+	labels  []string
+	static  bool
+	router  *httprouter.Router
+}
+
+// Host creates a new [Group] of routes that only match requests whose host (without the port) matches pattern, with
+// optional middlewares. Routes registered directly in r match requests of any host that is not matched by a host
+// pattern.
+//
+// A host pattern is a sequence of labels separated by dots, such as "admin.example.com". A label between curly
+// braces, such as in "{tenant}.api.example.com", matches any label in its position, and the matched value is
+// added to the URI parameters of the request (in this example, as "tenant"). Comparisons are case-insensitive and,
+// when both static and wildcard patterns match a host, the static one takes precedence. Among wildcard patterns,
+// the first one registered is preferred.
+//
+// Middlewares are applied as in [*Router.Group].
+//
+// If pattern is empty, contains a port (since the port of requests is not compared) or contains an empty label or
+// parameter name, a middleware is nil or r has already started serving requests, Host panics.
+func (r *Router) Host(pattern string, middlewares ...Middleware) *Group {
+	if slices.ContainsFunc(middlewares, func(m Middleware) bool { return m == nil }) {
+		panic("middlewares should not be nil")
+	}
+
+	r.checkNotFrozen()
+
+	h := newHost(strings.TrimSuffix(pattern, "."))
+
+	if registered, ok := r.hosts[h.pattern]; ok {
+		h = registered
+	} else {
+		r.hosts[h.pattern] = h
+		if !h.static {
+			r.wildcardHosts = append(r.wildcardHosts, h)
+		}
+	}
+
+	return newGroup(r, h, "", slices.Clone(middlewares))
+}
+
+func newHost(pattern string) *host {
+	if pattern == "" {
+		panic("host pattern should not be empty")
+	}
+
+	if _, _, err := net.SplitHostPort(pattern); err == nil {
+		panic("host pattern '" + pattern + "' should not contain a port")
+	}
+
+	labels := strings.Split(pattern, ".")
+	static := true
+
+	for i, label := range labels {
+		if label == "" {
+			panic("host pattern '" + pattern + "' should not contain empty labels")
+		}
+
+		if !isHostParameter(label) {
+			labels[i] = strings.ToLower(label)
+			continue
+		}
+
+		if len(label) == 2 {
+			panic("host pattern '" + pattern + "' should not contain empty parameter names")
+		}
+
+		static = false
+	}
+
+	return &host{strings.Join(labels, "."), labels, static, httprouter.New()}
+}
+
+// matchHost returns the host whose pattern matches hostname, or nil if there is none.
+func (r *Router) matchHost(hostname string) *host {
+	if len(r.hosts) == 0 {
+		return nil
+	}
+
+	hostname = normalizeHostname(hostname)
+
+	if h, ok := r.hosts[hostname]; ok && h.static {
+		return h
+	}
+
+	for _, h := range r.wildcardHosts {
+		if h.match(hostname, nil) {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// match reports whether hostname matches the pattern of h, adding the values of the parameters of the pattern to
+// parameters, if it is not nil.
+func (h *host) match(hostname string, parameters map[string]string) bool {
+	if strings.Count(hostname, ".") != len(h.labels)-1 {
+		return false
+	}
+
+	for _, label := range h.labels {
+		value, rest, _ := strings.Cut(hostname, ".")
+		hostname = rest
+
+		if !isHostParameter(label) {
+			if value != label {
+				return false
+			}
+
+			continue
+		}
+
+		if value == "" {
+			return false
+		}
+
+		if parameters != nil {
+			parameters[label[1:len(label)-1]] = value
+		}
+	}
+
+	return true
+}
+
+func isHostParameter(label string) bool {
+	return strings.HasPrefix(label, "{") && strings.HasSuffix(label, "}")
+}
+
+// normalizeHostname removes the port and the trailing dot of hostname, converting it to lower case.
+func normalizeHostname(hostname string) string {
+	if host, _, err := net.SplitHostPort(hostname); err == nil {
+		hostname = host
+	}
+
+	return strings.ToLower(strings.TrimSuffix(hostname, "."))
+}
@@ -0,0 +1,190 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/bind"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_Host(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		pattern     string
+		middlewares []lit.Middleware
+		panicValue  string
+	}{
+		{
+			description: "WhenPatternIsEmpty_ShouldPanic",
+			pattern:     "",
+			panicValue:  "host pattern should not be empty",
+		},
+		{
+			description: "WhenPatternContainsPort_ShouldPanic",
+			pattern:     "localhost:8080",
+			panicValue:  "host pattern 'localhost:8080' should not contain a port",
+		},
+		{
+			description: "WhenPatternContainsEmptyLabel_ShouldPanic",
+			pattern:     "api..example.com",
+			panicValue:  "host pattern 'api..example.com' should not contain empty labels",
+		},
+		{
+			description: "WhenPatternContainsEmptyParameterName_ShouldPanic",
+			pattern:     "{}.example.com",
+			panicValue:  "host pattern '{}.example.com' should not contain empty parameter names",
+		},
+		{
+			description: "WhenMiddlewaresContainsANilElement_ShouldPanic",
+			pattern:     "admin.example.com",
+			middlewares: []lit.Middleware{nil},
+			panicValue:  "middlewares should not be nil",
+		},
+		{
+			description: "WhenPatternIsValid_ShouldNotPanic",
+			pattern:     "{tenant}.api.example.com",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+
+			// Act
+			// Assert
+			if test.panicValue != "" {
+				require.PanicsWithValue(t, test.panicValue, func() {
+					router.Host(test.pattern, test.middlewares...)
+				})
+
+				return
+			}
+
+			require.NotPanics(t, func() {
+				router.Host(test.pattern, test.middlewares...)
+			})
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_Hosts(t *testing.T) {
+	t.Parallel()
+
+	type TenantRequest struct {
+		Tenant string `uri:"tenant"`
+		UserID int    `uri:"user_id"`
+	}
+
+	var (
+		writeHandler = func(body string) lit.Handler {
+			return func(r *lit.Request) lit.Response {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					w.Write([]byte(body))
+				})
+			}
+		}
+
+		tenantHandler = func(r *lit.Request) lit.Response {
+			req, err := bind.URIParameters[TenantRequest](r)
+			if err != nil {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+				})
+			}
+
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Write([]byte(req.Tenant + " " + r.URIParameters()["user_id"]))
+			})
+		}
+
+		setupRouter = func(r *lit.Router) {
+			r.GET("/users/:user_id", writeHandler("default"))
+			r.Host("admin.example.com").GET("/users/:user_id", writeHandler("admin"))
+			r.Host("{tenant}.api.example.com").GET("/users/:user_id", tenantHandler)
+			r.Host("{tenant}.example.com").GET("/users/:user_id", writeHandler("wildcard"))
+		}
+	)
+
+	tests := []struct {
+		description        string
+		host               string
+		path               string
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			description:        "WhenHostIsNotMatched_ShouldUseDefaultRoutes",
+			host:               "www.other.com",
+			path:               "/users/1",
+			expectedBody:       "default",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "WhenHostMatchesStaticPattern_ShouldUseHostRoutes",
+			host:               "admin.example.com",
+			path:               "/users/1",
+			expectedBody:       "admin",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "WhenHostHasPortAndDifferentCase_ShouldStillMatch",
+			host:               "Admin.Example.com:8080",
+			path:               "/users/1",
+			expectedBody:       "admin",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "WhenHostMatchesWildcardPattern_ShouldAddParameter",
+			host:               "acme.api.example.com",
+			path:               "/users/1",
+			expectedBody:       "acme 1",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "WhenHostMatchesAnotherWildcardPattern_ShouldUseIt",
+			host:               "acme.example.com",
+			path:               "/users/1",
+			expectedBody:       "wildcard",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "WhenHostMatchesButPathDoesNot_ShouldRespondNotFound",
+			host:               "admin.example.com",
+			path:               "/books/1",
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			setupRouter(router)
+
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			request.Host = test.host
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+		})
+	}
+}
@@ -55,6 +55,9 @@
 //
 // It is recommended to use the [Log] and [Recover] middlewares.
 //
+// Routes that share a path prefix and middlewares can be registered together using the [*Router.Group] method.
+// Different versions of an API can be served side by side using the [*Router.Versioning] method.
+//
 // Check the [package-level examples] for more use cases.
 //
 // # Model binding and receiving files
@@ -77,6 +80,37 @@
 //
 // Check [github.com/jvcoutinho/lit/render] package.
 //
+// # Handling errors
+//
+// Handlers that can fail may return an error instead of building the error response themselves. Register them with
+// [*Router.HandleFallible] (or convert them with [*Router.Fallible]) and translate their errors into responses in a
+// single place with [*Router.HandleError].
+//
+// # Typed handlers
+//
+// Lit can turn functions that receive and return plain Go values into handlers, binding, validating and rendering
+// them automatically.
+//
+// Check [github.com/jvcoutinho/lit/typed] package.
+//
+// # Cross-Origin Resource Sharing
+//
+// Lit can handle CORS preflight and actual requests with a configurable policy.
+//
+// Check [github.com/jvcoutinho/lit/cors] package.
+//
+// # Rate limiting
+//
+// Lit can limit the rate of requests per client with token bucket or sliding window algorithms.
+//
+// Check [github.com/jvcoutinho/lit/ratelimit] package.
+//
+// # Compression
+//
+// Lit can compress response bodies with the encoding negotiated with the client.
+//
+// Check [github.com/jvcoutinho/lit/compress] package.
+//
 // # Testing handlers
 //
 // Handlers can be unit tested in several ways. The simplest and idiomatic form is calling the handler with a crafted
@@ -0,0 +1,230 @@
+package lit
+
+import (
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// LogField is a field of the records emitted by [LogWith].
+type LogField string
+
+// Fields of the records emitted by [LogWith]. The values are the keys of the attributes.
+const (
+	// LogMethod is the method of the request.
+	LogMethod LogField = "method"
+
+	// LogRoute is the path pattern of the route that handled the request. It's omitted if no route matched it.
+	LogRoute LogField = "route"
+
+	// LogPath is the path of the request.
+	LogPath LogField = "path"
+
+	// LogStatus is the status code of the response.
+	LogStatus LogField = "status"
+
+	// LogDuration is the time taken to handle the request and write the response.
+	LogDuration LogField = "duration"
+
+	// LogBytes is the size of the response body.
+	LogBytes LogField = "bytes"
+
+	// LogRemoteAddress is the network address of the client.
+	LogRemoteAddress LogField = "remote_addr"
+
+	// LogRequestID is the ID of the request (see [WithRequestIDHeader]). It's omitted if the request has no ID.
+	LogRequestID LogField = "request_id"
+
+	// LogUserAgent is the User-Agent header of the request. It's omitted if the header is not set.
+	LogUserAgent LogField = "user_agent"
+)
+
+var defaultLogFields = []LogField{
+	LogMethod, LogRoute, LogPath, LogStatus, LogDuration, LogBytes, LogRemoteAddress, LogRequestID, LogUserAgent,
+}
+
+// LogOption configures [LogWith].
+type LogOption func(*logConfig)
+
+type logConfig struct {
+	fields          []LogField
+	levels          [5]slog.Level
+	sampling        float64
+	requestIDHeader string
+}
+
+// WithLogFields sets the fields of the records. By default, all fields are emitted.
+//
+// If fields is empty, WithLogFields panics.
+func WithLogFields(fields ...LogField) LogOption {
+	if len(fields) == 0 {
+		panic("fields should not be empty")
+	}
+
+	return func(c *logConfig) {
+		c.fields = fields
+	}
+}
+
+// WithLogLevel sets the level of the records of responses whose status code is in class, which is the first digit of
+// the status code (for instance, 4 for client errors). By default, records of 4xx responses have level
+// [slog.LevelWarn], records of 5xx responses have level [slog.LevelError] and the others have level [slog.LevelInfo].
+//
+// If class is not between 1 and 5, WithLogLevel panics.
+func WithLogLevel(class int, level slog.Level) LogOption {
+	if class < 1 || class > 5 {
+		panic("class should be between 1 and 5")
+	}
+
+	return func(c *logConfig) {
+		c.levels[class-1] = level
+	}
+}
+
+// WithLogSampling sets the fraction of successful requests (the ones responded with a status code lower than 400)
+// that are logged. Failed requests are always logged. By default, all requests are logged.
+//
+// If rate is not between 0 and 1, WithLogSampling panics.
+func WithLogSampling(rate float64) LogOption {
+	if rate < 0 || rate > 1 {
+		panic("rate should be between 0 and 1")
+	}
+
+	return func(c *logConfig) {
+		c.sampling = rate
+	}
+}
+
+// WithRequestIDHeader sets the header that holds the ID of the request. The ID is read from the request headers or,
+// if it's not set there, from the response headers, so that it can be assigned by an inner middleware. By default,
+// the header is "X-Request-Id".
+//
+// If key is empty, WithRequestIDHeader panics.
+func WithRequestIDHeader(key string) LogOption {
+	if key == "" {
+		panic("key should not be empty")
+	}
+
+	return func(c *logConfig) {
+		c.requestIDHeader = key
+	}
+}
+
+// LogWith is a middleware that emits one structured record per request to logger, with the message "request" and
+// the attributes defined by [LogField]. For example, with a [slog.JSONHandler]:
+//
+//	{"time":"...","level":"INFO","msg":"request","method":"GET","route":"/users/:id","path":"/users/1",
+//	"status":200,"duration":1250000,"bytes":27,"remote_addr":"192.0.2.1:1234","user_agent":"curl/8.4.0"}
+//
+// Requests whose handler returns a nil response are not logged.
+//
+// If logger is nil, LogWith panics.
+func LogWith(logger *slog.Logger, options ...LogOption) Middleware {
+	if logger == nil {
+		panic("logger should not be nil")
+	}
+
+	config := &logConfig{
+		fields:          defaultLogFields,
+		levels:          [5]slog.Level{slog.LevelInfo, slog.LevelInfo, slog.LevelInfo, slog.LevelWarn, slog.LevelError},
+		sampling:        1,
+		requestIDHeader: "X-Request-Id",
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+
+	return func(h Handler) Handler {
+		return func(r *Request) Response {
+			startTime := time.Now()
+
+			res := h(r)
+
+			if res == nil {
+				return nil
+			}
+
+			return ResponseFunc(func(w http.ResponseWriter) {
+				recorder := NewRecorder(w)
+				res.Write(recorder)
+
+				duration := time.Since(startTime)
+
+				if !config.sampled(recorder.StatusCode) {
+					return
+				}
+
+				level := config.level(recorder.StatusCode)
+				if !logger.Enabled(r.Context(), level) {
+					return
+				}
+
+				logger.LogAttrs(r.Context(), level, "request", config.attributes(r, recorder, duration)...)
+			})
+		}
+	}
+}
+
+func (c *logConfig) sampled(statusCode int) bool {
+	if statusCode >= http.StatusBadRequest || c.sampling >= 1 {
+		return true
+	}
+
+	return rand.Float64() < c.sampling
+}
+
+func (c *logConfig) level(statusCode int) slog.Level {
+	class := statusCode / 100
+	if class < 1 || class > 5 {
+		return slog.LevelError
+	}
+
+	return c.levels[class-1]
+}
+
+func (c *logConfig) attributes(r *Request, recorder *Recorder, duration time.Duration) []slog.Attr {
+	attributes := make([]slog.Attr, 0, len(c.fields))
+
+	for _, field := range c.fields {
+		key := string(field)
+
+		switch field {
+		case LogMethod:
+			attributes = append(attributes, slog.String(key, r.Method()))
+		case LogRoute:
+			if pattern := r.Route().Pattern; pattern != "" {
+				attributes = append(attributes, slog.String(key, pattern))
+			}
+		case LogPath:
+			attributes = append(attributes, slog.String(key, r.URL().Path))
+		case LogStatus:
+			attributes = append(attributes, slog.Int(key, recorder.StatusCode))
+		case LogDuration:
+			attributes = append(attributes, slog.Duration(key, duration))
+		case LogBytes:
+			attributes = append(attributes, slog.Int(key, recorder.ContentLength))
+		case LogRemoteAddress:
+			attributes = append(attributes, slog.String(key, r.Base().RemoteAddr))
+		case LogRequestID:
+			if id := c.requestID(r, recorder); id != "" {
+				attributes = append(attributes, slog.String(key, id))
+			}
+		case LogUserAgent:
+			if userAgent := r.Header().Get("User-Agent"); userAgent != "" {
+				attributes = append(attributes, slog.String(key, userAgent))
+			}
+		}
+	}
+
+	return attributes
+}
+
+func (c *logConfig) requestID(r *Request, recorder *Recorder) string {
+	if id := r.Header().Get(c.requestIDHeader); id != "" {
+		return id
+	}
+
+	return recorder.Header().Get(c.requestIDHeader)
+}
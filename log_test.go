@@ -0,0 +1,206 @@
+package lit_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogWith(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description    string
+		options        []lit.LogOption
+		path           string
+		header         http.Header
+		response       lit.Response
+		expectedOutput string
+	}{
+		{
+			description:    "WhenResponseIsNil_ShouldNotLog",
+			path:           "/users/1",
+			response:       nil,
+			expectedOutput: "",
+		},
+		{
+			description: "WhenStatusCodeIs2xx_ShouldLogInfo",
+			path:        "/users/1",
+			header:      http.Header{"User-Agent": {"curl/8.4.0"}, "X-Request-Id": {"abc"}},
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Write([]byte("log"))
+			}),
+			expectedOutput: `{"level":"INFO","msg":"request","method":"GET","route":"/users/:id","path":"/users/1",` +
+				`"status":200,"bytes":3,"remote_addr":"192.0.2.1:1234","request_id":"abc","user_agent":"curl/8.4.0"}` + "\n",
+		},
+		{
+			description: "WhenStatusCodeIs4xx_ShouldLogWarn",
+			path:        "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			expectedOutput: `{"level":"WARN","msg":"request","method":"GET","route":"/users/:id","path":"/users/1",` +
+				`"status":404,"bytes":0,"remote_addr":"192.0.2.1:1234"}` + "\n",
+		},
+		{
+			description: "WhenStatusCodeIs5xx_ShouldLogError",
+			options:     []lit.LogOption{lit.WithLogFields(lit.LogStatus)},
+			path:        "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			expectedOutput: `{"level":"ERROR","msg":"request","status":500}` + "\n",
+		},
+		{
+			description: "WhenLevelIsSet_ShouldUseIt",
+			options: []lit.LogOption{
+				lit.WithLogFields(lit.LogStatus),
+				lit.WithLogLevel(4, slog.LevelInfo),
+			},
+			path: "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			expectedOutput: `{"level":"INFO","msg":"request","status":400}` + "\n",
+		},
+		{
+			description: "WhenLevelIsDisabled_ShouldNotLog",
+			options:     []lit.LogOption{lit.WithLogLevel(2, slog.LevelDebug)},
+			path:        "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusOK)
+			}),
+			expectedOutput: "",
+		},
+		{
+			description: "WhenFieldsAreSelected_ShouldLogOnlyThem",
+			options:     []lit.LogOption{lit.WithLogFields(lit.LogPath, lit.LogMethod)},
+			path:        "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusOK)
+			}),
+			expectedOutput: `{"level":"INFO","msg":"request","path":"/users/1","method":"GET"}` + "\n",
+		},
+		{
+			description: "WhenRequestIDIsInResponseHeader_ShouldLogIt",
+			options: []lit.LogOption{
+				lit.WithLogFields(lit.LogRequestID),
+				lit.WithRequestIDHeader("X-Trace-Id"),
+			},
+			path: "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Header().Set("X-Trace-Id", "def")
+				w.WriteHeader(http.StatusOK)
+			}),
+			expectedOutput: `{"level":"INFO","msg":"request","request_id":"def"}` + "\n",
+		},
+		{
+			description: "WhenRequestIsNotSampled_ShouldNotLog",
+			options:     []lit.LogOption{lit.WithLogSampling(0)},
+			path:        "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusCreated)
+			}),
+			expectedOutput: "",
+		},
+		{
+			description: "WhenRequestFailsAndIsNotSampled_ShouldLog",
+			options: []lit.LogOption{
+				lit.WithLogFields(lit.LogStatus),
+				lit.WithLogSampling(0),
+			},
+			path: "/users/1",
+			response: lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusConflict)
+			}),
+			expectedOutput: `{"level":"WARN","msg":"request","status":409}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var output bytes.Buffer
+
+			logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey || a.Key == string(lit.LogDuration) {
+						return slog.Attr{}
+					}
+
+					return a
+				},
+			}))
+
+			router := lit.NewRouter()
+			router.Use(lit.LogWith(logger, test.options...))
+			router.GET("/users/:id", func(r *lit.Request) lit.Response {
+				return test.response
+			})
+
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			for key, values := range test.header {
+				request.Header[key] = values
+			}
+
+			// Act
+			router.ServeHTTP(httptest.NewRecorder(), request)
+
+			// Assert
+			require.Equal(t, test.expectedOutput, output.String())
+		})
+	}
+}
+
+func TestLogWith_WhenArgumentsAreInvalid_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description   string
+		function      func()
+		expectedPanic string
+	}{
+		{
+			description:   "NilLogger",
+			function:      func() { lit.LogWith(nil) },
+			expectedPanic: "logger should not be nil",
+		},
+		{
+			description:   "EmptyFields",
+			function:      func() { lit.WithLogFields() },
+			expectedPanic: "fields should not be empty",
+		},
+		{
+			description:   "InvalidClass",
+			function:      func() { lit.WithLogLevel(6, slog.LevelInfo) },
+			expectedPanic: "class should be between 1 and 5",
+		},
+		{
+			description:   "InvalidSamplingRate",
+			function:      func() { lit.WithLogSampling(1.5) },
+			expectedPanic: "rate should be between 0 and 1",
+		},
+		{
+			description:   "EmptyRequestIDHeader",
+			function:      func() { lit.WithRequestIDHeader("") },
+			expectedPanic: "key should not be empty",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.expectedPanic, test.function)
+		})
+	}
+}
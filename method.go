@@ -0,0 +1,37 @@
+package lit
+
+import "strings"
+
+// Extended HTTP methods, that can be registered with [*Router.Handle] like any other method.
+//
+// Since they are registered in the same way as the common ones, they take part in the Allow header of both Method
+// Not Allowed and OPTIONS responses.
+const (
+	// MethodQuery is the safe and idempotent method whose request body describes a query (IETF draft
+	// "The HTTP QUERY Method"). Its body can be bound with the functions of the bind package.
+	MethodQuery = "QUERY"
+
+	// MethodPropfind retrieves properties of a resource (RFC 4918).
+	MethodPropfind = "PROPFIND"
+
+	// MethodReport retrieves a report about a resource (RFC 3253).
+	MethodReport = "REPORT"
+
+	// MethodPurge invalidates cached copies of a resource, as understood by several caching proxies.
+	MethodPurge = "PURGE"
+)
+
+// tokenCharacters are the characters allowed in a method, besides letters and digits (RFC 9110, section 5.6.2).
+const tokenCharacters = "!#$%&'*+-.^_`|~"
+
+// isToken reports whether method is a valid method name.
+func isToken(method string) bool {
+	for _, c := range method {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && !strings.ContainsRune(tokenCharacters, c) {
+			return false
+		}
+	}
+
+	return true
+}
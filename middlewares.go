@@ -11,6 +11,9 @@ import (
 
 // Recover is a simple middleware that recovers if h panics, responding a 500 Internal Server Error with
 // the panic value as the body and logging the stack trace in os.Stderr.
+//
+// To hide the panic value from clients, report panics elsewhere or recover from panics raised while writing
+// responses, use [RecoverWith].
 func Recover(h Handler) Handler {
 	return func(r *Request) (res Response) {
 		defer func() {
@@ -35,6 +38,8 @@ func Recover(h Handler) Handler {
 //   - The client's remote address;
 //   - The duration of the request;
 //   - The content length of the response body.
+//
+// For structured logging with [log/slog], use [LogWith].
 func Log(h Handler) Handler {
 	return func(r *Request) Response {
 		startTime := time.Now()
@@ -0,0 +1,176 @@
+package lit
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const mountedPathParameter = "mounted_path"
+
+// anyMethod is the method under which mounted handlers are registered in the mount trees.
+const anyMethod = "*"
+
+var mountMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+	MethodQuery,
+	MethodPropfind,
+	MethodReport,
+	MethodPurge,
+}
+
+// Mount registers handler for every request whose path is prefix or starts with prefix followed by a slash,
+// with optional local middlewares, whatever its method. Routes are registered for the methods defined in [net/http]
+// and for the extended methods defined in this package, such as [MethodQuery] and [MethodPropfind]. Requests with
+// other methods, such as MKCOL or LOCK, are forwarded unless a route for their method matches their path, and the
+// Method of their [RouteInfo] is empty.
+//
+// Before calling handler, Mount strips prefix from the request's URL path (and from its escaped form, as
+// [http.StripPrefix] does), so that handler sees paths relative to the mounting point. Since handler is adapted
+// into a [Handler], global and local middlewares are applied to it as in [*Router.Handle]. This also makes it
+// possible to compose routers, mounting a child [*Router] under a parent one: in this case, the global middlewares
+// of the parent are applied before the ones of the child.
+//
+// Since it registers a catch-all route, no other route can be registered under prefix.
+//
+// If prefix does not contain a leading slash, handler is nil or a middleware is nil, Mount panics.
+func (r *Router) Mount(prefix string, handler http.Handler, middlewares ...Middleware) {
+	newGroup(r, nil, "", nil).Mount(prefix, handler, middlewares...)
+}
+
+// Mount registers handler for every request whose path is the group prefix followed by prefix (or starts with it
+// followed by a slash), with optional local middlewares.
+//
+// See [*Router.Mount].
+func (g *Group) Mount(prefix string, handler http.Handler, middlewares ...Middleware) {
+	if handler == nil {
+		panic("handler should not be nil")
+	}
+
+	mounted := g.Group(prefix)
+
+	litHandler := Handler(func(r *Request) Response {
+		return ResponseFunc(func(w http.ResponseWriter) {
+			handler.ServeHTTP(w, stripPrefix(r.Base(), r.URIParameters()[mountedPathParameter]))
+		})
+	})
+
+	middlewares = append(slices.Clone(mounted.middlewares), middlewares...)
+
+	name := handlerName(handler)
+
+	patterns := []string{mounted.prefix + "/*" + mountedPathParameter}
+	if mounted.prefix != "" {
+		patterns = append([]string{mounted.prefix}, patterns...)
+	}
+
+	for _, method := range mountMethods {
+		for _, pattern := range patterns {
+			g.router.handle(mounted.host, pattern, method, litHandler, middlewares).handlerName = name
+		}
+	}
+
+	for _, pattern := range patterns {
+		g.router.mount(mounted.host, pattern, litHandler, name, middlewares)
+	}
+}
+
+// mount registers handler for pattern and any method in the mount tree of host, so that requests whose methods don't
+// have routes can be forwarded to it.
+func (r *Router) mount(host *host, pattern string, handler Handler, name string, middlewares []Middleware) {
+	path, constraints := r.parsePattern(pattern)
+
+	route := &Route{
+		router:      r,
+		host:        host,
+		pattern:     pattern,
+		path:        path,
+		constraints: constraints,
+		handler:     handler,
+		handlerName: name,
+		middlewares: slices.Clone(middlewares),
+	}
+
+	tree := r.tree(host)
+
+	if r.mountTrees == nil {
+		r.mountTrees = make(map[*httprouter.Router]*httprouter.Router)
+	}
+
+	mountTree, ok := r.mountTrees[tree]
+	if !ok {
+		mountTree = httprouter.New()
+		r.mountTrees[tree] = mountTree
+	}
+
+	mountTree.Handle(anyMethod, path, r.dispatch(route))
+
+	r.mounts = append(r.mounts, route)
+}
+
+// serveMounted serves request with the mounted handler of tree that matches its path, if its method is not one of
+// the methods of the routes registered by Mount and no route for its method matches its path, reporting whether
+// there is one.
+func (r *Router) serveMounted(tree *httprouter.Router, writer http.ResponseWriter, request *http.Request) bool {
+	mountTree, ok := r.mountTrees[tree]
+	if !ok || slices.Contains(mountMethods, request.Method) {
+		return false
+	}
+
+	path, raw := r.paths.path(request)
+
+	if handle, _, _ := tree.Lookup(request.Method, path); handle != nil {
+		return false
+	}
+
+	handle, parameters, _ := mountTree.Lookup(anyMethod, path)
+	if handle == nil {
+		return false
+	}
+
+	if raw {
+		unescapeParameters(parameters)
+	}
+
+	handle(writer, request, parameters)
+
+	return true
+}
+
+func stripPrefix(req *http.Request, path string) *http.Request {
+	if path == "" {
+		path = "/"
+	}
+
+	stripped := new(http.Request)
+	*stripped = *req
+	stripped.URL = new(url.URL)
+	*stripped.URL = *req.URL
+	stripped.URL.Path = path
+	stripped.URL.RawPath = ""
+
+	// The escaped path is kept, starting from the slash whose suffix is the escaped form of path.
+	rawPath := req.URL.RawPath
+	for i := 0; i < len(rawPath); i++ {
+		if rawPath[i] != '/' {
+			continue
+		}
+
+		if unescaped, err := url.PathUnescape(rawPath[i:]); err == nil && unescaped == path {
+			stripped.URL.RawPath = rawPath[i:]
+			break
+		}
+	}
+
+	return stripped
+}
@@ -0,0 +1,258 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_Mount(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		description string
+		prefix      string
+		handler     http.Handler
+		middlewares []lit.Middleware
+		panicValue  string
+	}{
+		{
+			description: "WhenPrefixDoesNotContainALeadingSlash_ShouldPanic",
+			prefix:      "debug",
+			handler:     handler,
+			panicValue:  "prefix should begin with '/'",
+		},
+		{
+			description: "WhenHandlerIsNil_ShouldPanic",
+			prefix:      "/debug",
+			handler:     nil,
+			panicValue:  "handler should not be nil",
+		},
+		{
+			description: "WhenMiddlewaresContainsANilElement_ShouldPanic",
+			prefix:      "/debug",
+			handler:     handler,
+			middlewares: []lit.Middleware{nil},
+			panicValue:  "middlewares should not be nil",
+		},
+		{
+			description: "ShouldRegisterCatchAllRoute",
+			prefix:      "/debug",
+			handler:     handler,
+			panicValue:  `a handle is already registered for path '/debug'`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+
+			// Act
+			// Assert
+			require.PanicsWithValue(t, test.panicValue, func() {
+				router.Mount(test.prefix, test.handler, test.middlewares...)
+
+				// Attempting to mount again in order to deliberately create a panic. This will only happen
+				// if the handler is successfully registered.
+				router.Mount(test.prefix, test.handler, test.middlewares...)
+			})
+		})
+	}
+}
+
+func TestRouter_Mount_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	var (
+		printPathHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.Method + " " + r.URL.Path))
+		})
+
+		printUsersHandler = func(r *lit.Request) lit.Response {
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Write([]byte("users " + r.URIParameters()["id"]))
+			})
+		}
+
+		writeMiddleware = func(name string) lit.Middleware {
+			return func(h lit.Handler) lit.Handler {
+				return func(r *lit.Request) lit.Response {
+					res := h(r)
+
+					return lit.ResponseFunc(func(w http.ResponseWriter) {
+						w.Write([]byte(name + "("))
+						res.Write(w)
+						w.Write([]byte(")"))
+					})
+				}
+			}
+		}
+	)
+
+	tests := []struct {
+		description        string
+		setupRouter        func(*lit.Router)
+		request            *http.Request
+		expectedBody       string
+		expectedStatusCode int
+	}{
+		{
+			description: "WhenPathIsPrefix_ShouldCallHandlerWithRootPath",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/debug", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/debug", nil),
+			expectedBody:       "GET /",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenPathStartsWithPrefix_ShouldStripPrefix",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/debug", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodPost, "/debug/pprof/profile", nil),
+			expectedBody:       "POST /pprof/profile",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenMethodIsExtended_ShouldCallHandler",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/dav", printPathHandler)
+			},
+			request:            httptest.NewRequest(lit.MethodPropfind, "/dav/x", nil),
+			expectedBody:       "PROPFIND /x",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenMethodIsCustom_ShouldCallHandler",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/dav", printPathHandler)
+			},
+			request:            httptest.NewRequest("MKCOL", "/dav/x", nil),
+			expectedBody:       "MKCOL /x",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenMethodIsCustom_AndPathIsPrefix_ShouldCallHandlerWithRootPath",
+			setupRouter: func(r *lit.Router) {
+				r.Group("/users/:id").Mount("/dav", printPathHandler)
+			},
+			request:            httptest.NewRequest("LOCK", "/users/1/dav", nil),
+			expectedBody:       "LOCK /",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenMethodIsCustom_AndRouteMatches_ShouldUseRoute",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/dav", printPathHandler)
+				r.Handle("/dav/x", "MKCOL", printUsersHandler)
+			},
+			request:            httptest.NewRequest("MKCOL", "/dav/x", nil),
+			expectedBody:       "users ",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenMethodIsCustom_AndPathDoesNotStartWithPrefix_ShouldRespondNotFound",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/dav", printPathHandler)
+			},
+			request:            httptest.NewRequest("MKCOL", "/files/x", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "WhenPathHasEscapedSlash_ShouldKeepIt",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/files", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(r.URL.Path + " " + r.URL.EscapedPath()))
+				}))
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/files/a%2Fb/c", nil),
+			expectedBody:       "/a/b/c /a%2Fb/c",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenPathDoesNotStartWithPrefix_ShouldRespondNotFound",
+			setupRouter: func(r *lit.Router) {
+				r.Mount("/debug", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/debugging", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			description: "ShouldApplyGlobalAndLocalMiddlewares",
+			setupRouter: func(r *lit.Router) {
+				r.Use(writeMiddleware("G"))
+				r.Mount("/debug", printPathHandler, writeMiddleware("L"))
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/debug/vars", nil),
+			expectedBody:       "G(L(GET /vars))",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenMountedInGroup_ShouldPrefixPathAndApplyGroupMiddlewares",
+			setupRouter: func(r *lit.Router) {
+				r.Group("/internal", writeMiddleware("Group")).Mount("/debug", printPathHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/internal/debug/vars", nil),
+			expectedBody:       "Group(GET /vars)",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenHandlerIsARouter_ShouldDispatchToChildRoutes",
+			setupRouter: func(r *lit.Router) {
+				child := lit.NewRouter()
+				child.Use(writeMiddleware("Child"))
+				child.GET("/users/:id", printUsersHandler)
+
+				r.Use(writeMiddleware("Parent"))
+				r.Mount("/api", child)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/users/1", nil),
+			expectedBody:       "Parent(Child(users 1))",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "WhenHandlerIsARouter_AndChildRouteIsNotFound_ShouldRespondChildNotFound",
+			setupRouter: func(r *lit.Router) {
+				child := lit.NewRouter()
+				child.GET("/users/:id", printUsersHandler)
+
+				r.Mount("/api", child)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/api/books/1", nil),
+			expectedBody:       "404 page not found\n",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			test.setupRouter(router)
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, test.request)
+
+			// Assert
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package lit
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// pathPolicy defines how request paths that don't exactly match a route are handled.
+type pathPolicy struct {
+	redirectTrailingSlash bool
+	strictSlash           bool
+	redirectFixedPath     bool
+	caseInsensitive       bool
+	rawPath               bool
+	foldedTrees           map[*httprouter.Router]*httprouter.Router
+	foldedRoutes          map[foldedRoute]bool
+	methods               map[*httprouter.Router][]string
+}
+
+// foldedRoute identifies a route registered in a folded tree.
+type foldedRoute struct {
+	tree    *httprouter.Router
+	method  string
+	pattern string
+}
+
+// WithRedirectTrailingSlash sets whether requests whose path only matches a route if the trailing slash is added or
+// removed are redirected to it, with 301 Moved Permanently for GET requests and 308 Permanent Redirect otherwise.
+// If disabled, these requests are handled by the matched route in place, without redirection. By default, they are
+// redirected.
+//
+// This option has no effect if [WithStrictSlash] is enabled.
+func WithRedirectTrailingSlash(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.paths.redirectTrailingSlash = enabled
+	}
+}
+
+// WithStrictSlash sets whether paths with and without a trailing slash are considered distinct, so that requests
+// whose path only matches a route if the trailing slash is added or removed are handled by the Not Found handler.
+// By default, they are not.
+func WithStrictSlash(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.paths.strictSlash = enabled
+	}
+}
+
+// WithRedirectFixedPath sets whether requests whose path only matches a route after being cleaned (see
+// [httprouter.CleanPath]) and compared case-insensitively, such as "/USERS//1" for the pattern "/users/:id", are
+// redirected to the canonical path, with 301 Moved Permanently for GET requests and 308 Permanent Redirect otherwise.
+// If disabled, these requests are handled by the Not Found handler. By default, they are redirected.
+//
+// This option has no effect if [WithCaseInsensitivePaths] is enabled.
+func WithRedirectFixedPath(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.paths.redirectFixedPath = enabled
+	}
+}
+
+// WithCaseInsensitivePaths sets whether requests whose path only matches a route after being cleaned (see
+// [httprouter.CleanPath]) and compared case-insensitively, such as "/USERS//1" for the pattern "/users/:id", are
+// handled by the matched route in place. Parameter values keep their original case. When several routes differ only
+// by case (or by the names of their parameters), the first registered is preferred. Routes that would conflict if
+// their paths were compared case-insensitively, such as "/A/b" and "/a/:id", make the registration panic.
+//
+// By default, these requests are redirected to the canonical path, with 301 Moved Permanently for GET requests and
+// 308 Permanent Redirect otherwise (see [WithRedirectFixedPath]).
+func WithCaseInsensitivePaths(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.paths.caseInsensitive = enabled
+	}
+}
+
+// WithRawPathMatching sets whether routes are matched against the escaped path of requests, so that encoded slashes
+// ("%2F") don't separate segments. For instance, "/files/a%2Fb" matches the pattern "/files/:name", and "name" is
+// "a/b". The escaped path is only used when it differs from the default encoding of the path. URI parameters
+// are unescaped. By default, routes are matched against the unescaped path.
+func WithRawPathMatching(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.paths.rawPath = enabled
+	}
+}
+
+// rewrites reports whether requests can be served by a route other than the one that exactly matches their path.
+func (p pathPolicy) rewrites() bool {
+	return p.rawPath || p.caseInsensitive || (!p.redirectTrailingSlash && !p.strictSlash)
+}
+
+// serveRewritten serves request with the route of tree that matches its path according to the path policy of r,
+// reporting whether there is one.
+func (r *Router) serveRewritten(tree *httprouter.Router, writer http.ResponseWriter, request *http.Request) bool {
+	path, raw := r.paths.path(request)
+
+	handle, parameters, redirect := tree.Lookup(request.Method, path)
+
+	if handle == nil && redirect && !r.paths.redirectTrailingSlash && !r.paths.strictSlash {
+		handle, parameters, _ = tree.Lookup(request.Method, toggleTrailingSlash(path))
+	}
+
+	if handle != nil {
+		if raw {
+			unescapeParameters(parameters)
+		}
+
+		handle(writer, request, parameters)
+
+		return true
+	}
+
+	if folded, ok := r.paths.foldedTrees[tree]; ok {
+		path := foldPath(httprouter.CleanPath(path))
+
+		handle, parameters, redirect = folded.Lookup(request.Method, path)
+
+		if handle == nil && redirect && !r.paths.strictSlash {
+			handle, parameters, _ = folded.Lookup(request.Method, toggleTrailingSlash(path))
+		}
+
+		if handle != nil {
+			handle(writer, request, parameters)
+			return true
+		}
+	}
+
+	if r.serveNotAllowed(tree, writer, request, r.allowed(tree, path, raw, request.Method)) {
+		return true
+	}
+
+	// The unescaped path must not be matched, since it can have more segments than the escaped one.
+	if raw {
+		tree.NotFound.ServeHTTP(writer, request)
+		return true
+	}
+
+	return false
+}
+
+// allowed returns the methods other than method of the routes of tree that match path according to the path policy
+// of r, in the format of the Allow header. It's empty if there are none.
+//
+// Unescaped paths are only matched against the folded tree, since the exact matches are found by tree itself.
+func (r *Router) allowed(tree *httprouter.Router, path string, raw bool, method string) string {
+	folded, caseInsensitive := r.paths.foldedTrees[tree]
+	foldedPath := foldPath(httprouter.CleanPath(path))
+
+	var allowed []string
+
+	for _, m := range r.paths.methods[tree] {
+		if m == method || m == http.MethodOptions {
+			continue
+		}
+
+		var handle httprouter.Handle
+
+		if raw {
+			handle, _, _ = tree.Lookup(m, path)
+		}
+
+		if handle == nil && caseInsensitive {
+			handle, _, _ = folded.Lookup(m, foldedPath)
+		}
+
+		if handle != nil {
+			allowed = append(allowed, m)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return ""
+	}
+
+	allowed = append(allowed, http.MethodOptions)
+	slices.Sort(allowed)
+
+	return strings.Join(allowed, ", ")
+}
+
+// serveNotAllowed serves request with the OPTIONS or the Method Not Allowed handler of tree, as tree itself does when
+// the path of req matches routes for methods other than its own, reporting whether it does. allow is the Allow
+// header of the response.
+func (r *Router) serveNotAllowed(tree *httprouter.Router, w http.ResponseWriter, req *http.Request, allow string) bool {
+	if allow == "" {
+		return false
+	}
+
+	if req.Method == http.MethodOptions {
+		if !tree.HandleOPTIONS {
+			return false
+		}
+
+		w.Header().Set("Allow", allow)
+
+		if tree.GlobalOPTIONS != nil {
+			tree.GlobalOPTIONS.ServeHTTP(w, req)
+		}
+
+		return true
+	}
+
+	if !tree.HandleMethodNotAllowed {
+		return false
+	}
+
+	w.Header().Set("Allow", allow)
+
+	if tree.MethodNotAllowed != nil {
+		tree.MethodNotAllowed.ServeHTTP(w, req)
+	} else {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+
+	return true
+}
+
+// path returns the path of request used to match routes, reporting whether it is escaped.
+func (p pathPolicy) path(request *http.Request) (string, bool) {
+	if p.rawPath && request.URL.RawPath != "" {
+		return request.URL.RawPath, true
+	}
+
+	return request.URL.Path, false
+}
+
+// register registers handle for method and path in tree and, if paths are case-insensitive, in its folded tree.
+func (r *Router) register(tree *httprouter.Router, method string, path string, handle httprouter.Handle) {
+	tree.Handle(method, path, handle)
+
+	if r.paths.methods == nil {
+		r.paths.methods = make(map[*httprouter.Router][]string)
+	}
+
+	if !slices.Contains(r.paths.methods[tree], method) {
+		r.paths.methods[tree] = append(r.paths.methods[tree], method)
+	}
+
+	if r.paths.caseInsensitive {
+		r.fold(tree, method, path, handle)
+	}
+}
+
+// fold registers handle in the folded tree of tree, in which the static parts of the paths are in lower case and the
+// parameters are named after their positions, unless a route that differs from path only by case or by the names of
+// its parameters has already been registered.
+func (r *Router) fold(tree *httprouter.Router, method string, path string, handle httprouter.Handle) {
+	if r.paths.foldedTrees == nil {
+		r.paths.foldedTrees = make(map[*httprouter.Router]*httprouter.Router)
+		r.paths.foldedRoutes = make(map[foldedRoute]bool)
+	}
+
+	folded, ok := r.paths.foldedTrees[tree]
+	if !ok {
+		folded = httprouter.New()
+		r.paths.foldedTrees[tree] = folded
+	}
+
+	key := foldedRoute{tree, method, foldPattern(path)}
+	if r.paths.foldedRoutes[key] {
+		return
+	}
+
+	// The values of the parameters found in the folded tree are in lower case, so they are extracted again from
+	// the original path.
+	folded.Handle(method, key.pattern, func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		requestPath, raw := r.paths.path(req)
+
+		parameters := extractParameters(path, httprouter.CleanPath(requestPath))
+		if raw {
+			unescapeParameters(parameters)
+		}
+
+		handle(w, req, parameters)
+	})
+
+	r.paths.foldedRoutes[key] = true
+}
+
+// extractParameters returns the values of the parameters of pattern in path, that is known to match it.
+func extractParameters(pattern string, path string) httprouter.Params {
+	var (
+		patternSegments = strings.Split(pattern, "/")
+		pathSegments    = strings.Split(path, "/")
+		parameters      httprouter.Params
+	)
+
+	for i, segment := range patternSegments {
+		if i >= len(pathSegments) || segment == "" {
+			continue
+		}
+
+		switch segment[0] {
+		case ':':
+			parameters = append(parameters, httprouter.Param{Key: segment[1:], Value: pathSegments[i]})
+		case '*':
+			parameters = append(parameters, httprouter.Param{
+				Key:   segment[1:],
+				Value: "/" + strings.Join(pathSegments[i:], "/"),
+			})
+		}
+	}
+
+	return parameters
+}
+
+func unescapeParameters(parameters httprouter.Params) {
+	for i, parameter := range parameters {
+		if value, err := url.PathUnescape(parameter.Value); err == nil {
+			parameters[i].Value = value
+		}
+	}
+}
+
+// foldPattern converts the ASCII letters of pattern to lower case and names its parameters after their positions.
+func foldPattern(pattern string) string {
+	var (
+		folded     strings.Builder
+		parameters int
+		parameter  bool
+	)
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case c == ':' || c == '*':
+			parameter = true
+			parameters++
+
+			folded.WriteByte(c)
+			folded.WriteString(strconv.Itoa(parameters))
+		case c == '/':
+			parameter = false
+
+			folded.WriteByte(c)
+		case !parameter && c >= 'A' && c <= 'Z':
+			folded.WriteByte(c + 'a' - 'A')
+		case !parameter:
+			folded.WriteByte(c)
+		}
+	}
+
+	return folded.String()
+}
+
+// foldPath converts the ASCII letters of path to lower case.
+func foldPath(path string) string {
+	folded := []byte(path)
+
+	for i, c := range folded {
+		if c >= 'A' && c <= 'Z' {
+			folded[i] = c + 'a' - 'A'
+		}
+	}
+
+	return string(folded)
+}
+
+func toggleTrailingSlash(path string) string {
+	if len(path) > 1 && strings.HasSuffix(path, "/") {
+		return path[:len(path)-1]
+	}
+
+	return path + "/"
+}
@@ -0,0 +1,251 @@
+package lit_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_ServeHTTP_PathPolicies(t *testing.T) {
+	t.Parallel()
+
+	respondParameters := func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			fmt.Fprintf(w, "%s %v", r.Route().Pattern, r.URIParameters())
+		})
+	}
+
+	tests := []struct {
+		description        string
+		options            []lit.RouterOption
+		method             string
+		path               string
+		expectedStatusCode int
+		expectedLocation   string
+		expectedAllow      string
+		expectedBody       string
+	}{
+		{
+			description:        "GivenDefaultPolicy_WhenTrailingSlashIsExtra_ShouldRedirect",
+			method:             http.MethodGet,
+			path:               "/users/",
+			expectedStatusCode: http.StatusMovedPermanently,
+			expectedLocation:   "/users",
+		},
+		{
+			description:        "GivenDefaultPolicy_WhenTrailingSlashIsExtraInPOST_ShouldRedirectPermanently",
+			method:             http.MethodPost,
+			path:               "/users/",
+			expectedStatusCode: http.StatusPermanentRedirect,
+			expectedLocation:   "/users",
+		},
+		{
+			description:        "GivenDefaultPolicy_WhenPathHasDifferentCase_ShouldRedirect",
+			method:             http.MethodGet,
+			path:               "/USERS",
+			expectedStatusCode: http.StatusMovedPermanently,
+			expectedLocation:   "/users",
+		},
+		{
+			description:        "GivenRedirectTrailingSlashIsDisabled_WhenTrailingSlashIsExtra_ShouldServeInPlace",
+			options:            []lit.RouterOption{lit.WithRedirectTrailingSlash(false)},
+			method:             http.MethodPost,
+			path:               "/users/",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/users map[]",
+		},
+		{
+			description:        "GivenRedirectTrailingSlashIsDisabled_WhenTrailingSlashIsMissing_ShouldServeInPlace",
+			options:            []lit.RouterOption{lit.WithRedirectTrailingSlash(false)},
+			method:             http.MethodGet,
+			path:               "/books/1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/books/:id/ map[id:1]",
+		},
+		{
+			description:        "GivenStrictSlash_WhenTrailingSlashIsExtra_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithStrictSlash(true)},
+			method:             http.MethodGet,
+			path:               "/users/",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+		{
+			description:        "GivenStrictSlash_AndRedirectTrailingSlashIsDisabled_WhenTrailingSlashIsExtra_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithStrictSlash(true), lit.WithRedirectTrailingSlash(false)},
+			method:             http.MethodGet,
+			path:               "/users/",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+		{
+			description:        "GivenRedirectFixedPathIsDisabled_WhenPathHasDifferentCase_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithRedirectFixedPath(false)},
+			method:             http.MethodPost,
+			path:               "/USERS",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+		{
+			description:        "GivenRedirectFixedPathIsDisabled_AndStrictSlash_WhenPathIsNotClean_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithRedirectFixedPath(false), lit.WithStrictSlash(true)},
+			method:             http.MethodPost,
+			path:               "//users",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+		{
+			description:        "GivenCaseInsensitivePaths_WhenPathHasDifferentCase_ShouldServeInPlaceKeepingParameterCase",
+			options:            []lit.RouterOption{lit.WithCaseInsensitivePaths(true)},
+			method:             http.MethodGet,
+			path:               "/USERS/John/Files/Docs/CV.pdf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/users/:name/files/*filepath map[filepath:/Docs/CV.pdf name:John]",
+		},
+		{
+			description:        "GivenCaseInsensitivePaths_WhenPathIsNotClean_ShouldServeInPlace",
+			options:            []lit.RouterOption{lit.WithCaseInsensitivePaths(true)},
+			method:             http.MethodGet,
+			path:               "/Users//John/../Jane",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/users/:name map[name:Jane]",
+		},
+		{
+			description:        "GivenCaseInsensitivePaths_WhenPathDoesNotMatch_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithCaseInsensitivePaths(true)},
+			method:             http.MethodGet,
+			path:               "/ORDERS",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+		{
+			description:        "GivenCaseInsensitivePaths_WhenMethodIsOPTIONS_ShouldRespondAllowedMethods",
+			options:            []lit.RouterOption{lit.WithCaseInsensitivePaths(true)},
+			method:             http.MethodOptions,
+			path:               "/USERS",
+			expectedStatusCode: http.StatusOK,
+			expectedAllow:      "GET, OPTIONS, POST",
+		},
+		{
+			description:        "GivenCaseInsensitivePaths_WhenMethodIsNotAllowed_ShouldRespondMethodNotAllowed",
+			options:            []lit.RouterOption{lit.WithCaseInsensitivePaths(true)},
+			method:             http.MethodDelete,
+			path:               "/Users",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedAllow:      "GET, OPTIONS, POST",
+			expectedBody:       "Method Not Allowed\n",
+		},
+		{
+			description:        "GivenCaseInsensitivePaths_WhenRoutesDifferOnlyByCase_ShouldServeFirstRegistered",
+			options:            []lit.RouterOption{lit.WithCaseInsensitivePaths(true)},
+			method:             http.MethodGet,
+			path:               "/ORDERS/1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/Orders/:id map[id:1]",
+		},
+		{
+			description:        "GivenDefaultPolicy_WhenPathHasEncodedSlash_ShouldMatchUnescapedPath",
+			method:             http.MethodGet,
+			path:               "/files/docs%2Fcv.pdf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/files/:name/:file map[file:cv.pdf name:docs]",
+		},
+		{
+			description:        "GivenRawPathMatching_WhenPathHasEncodedSlash_ShouldMatchEscapedPath",
+			options:            []lit.RouterOption{lit.WithRawPathMatching(true)},
+			method:             http.MethodGet,
+			path:               "/files/docs%2Fcv.pdf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/files/:name map[name:docs/cv.pdf]",
+		},
+		{
+			description:        "GivenRawPathMatching_WhenPathHasNoEncodedCharacters_ShouldMatchPath",
+			options:            []lit.RouterOption{lit.WithRawPathMatching(true)},
+			method:             http.MethodGet,
+			path:               "/files/docs/cv.pdf",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "/files/:name/:file map[file:cv.pdf name:docs]",
+		},
+		{
+			description:        "GivenRawPathMatching_WhenMethodIsOPTIONS_ShouldRespondAllowedMethods",
+			options:            []lit.RouterOption{lit.WithRawPathMatching(true)},
+			method:             http.MethodOptions,
+			path:               "/files/docs%2Fcv.pdf",
+			expectedStatusCode: http.StatusOK,
+			expectedAllow:      "GET, OPTIONS",
+		},
+		{
+			description:        "GivenRawPathMatching_WhenMethodIsNotAllowed_ShouldRespondMethodNotAllowed",
+			options:            []lit.RouterOption{lit.WithRawPathMatching(true)},
+			method:             http.MethodDelete,
+			path:               "/files/docs%2Fcv.pdf",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedAllow:      "GET, OPTIONS",
+			expectedBody:       "Method Not Allowed\n",
+		},
+		{
+			description:        "GivenRawPathMatching_WhenEscapedPathDoesNotMatch_ShouldRespondNotFound",
+			options:            []lit.RouterOption{lit.WithRawPathMatching(true)},
+			method:             http.MethodGet,
+			path:               "/users/a%2Fb/c",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter(test.options...)
+
+			router.GET("/users", respondParameters)
+			router.POST("/users", respondParameters)
+			router.GET("/users/:name", respondParameters)
+			router.GET("/users/:name/files/*filepath", respondParameters)
+			router.GET("/books/:id/", respondParameters)
+			router.GET("/files/:name", respondParameters)
+			router.GET("/files/:name/:file", respondParameters)
+			router.GET("/Orders/:id", respondParameters)
+			router.GET("/orders/:code", respondParameters)
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedLocation, recorder.Header().Get("Location"))
+			require.Equal(t, test.expectedAllow, recorder.Header().Get("Allow"))
+
+			if test.expectedBody != "" {
+				require.Equal(t, test.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_Handle_WhenRoutesConflictCaseInsensitively_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	router := lit.NewRouter(lit.WithCaseInsensitivePaths(true))
+	router.GET("/Users/me", func(r *lit.Request) lit.Response {
+		return nil
+	})
+
+	// Act
+	// Assert
+	require.Panics(t, func() {
+		router.GET("/users/:id", func(r *lit.Request) lit.Response {
+			return nil
+		})
+	})
+}
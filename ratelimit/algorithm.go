@@ -0,0 +1,184 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Algorithm decides whether requests are allowed, given the state of their key.
+type Algorithm interface {
+	// Allow records a request made at now in state, that is the zero value for keys without previous requests (or
+	// whose state has expired), returning the decision.
+	Allow(state *State, now time.Time) Decision
+
+	// TTL is how long the state of a key must be kept after its last request. After that, the state is equivalent
+	// to the zero value.
+	TTL() time.Duration
+}
+
+// State of a key, managed by an [Algorithm] and kept by a [Store]. Its fields are exported so that stores can
+// serialize it.
+type State struct {
+	// Tokens left in the bucket (token bucket) or requests in the current window (sliding window).
+	Value float64
+
+	// Requests in the previous window (sliding window).
+	Previous float64
+
+	// Time of the last request (token bucket) or start of the current window (sliding window).
+	Time time.Time
+}
+
+// Decision of an [Algorithm] about a request.
+type Decision struct {
+	// Whether the request is allowed.
+	Allowed bool
+
+	// Maximum number of requests in the period of the algorithm.
+	Limit int
+
+	// Number of requests that can still be made.
+	Remaining int
+
+	// Time until the quota is fully restored.
+	Reset time.Duration
+
+	// Time until a request can be allowed again. It's zero if the request is allowed.
+	RetryAfter time.Duration
+}
+
+// TokenBucket returns an [Algorithm] that allows bursts of up to limit requests, refilling the bucket at a rate
+// of limit requests per period.
+//
+// If limit or period are not positive, TokenBucket panics.
+func TokenBucket(limit int, period time.Duration) Algorithm {
+	if limit <= 0 {
+		panic("limit should be positive")
+	}
+
+	if period <= 0 {
+		panic("period should be positive")
+	}
+
+	return tokenBucket{limit, period}
+}
+
+type tokenBucket struct {
+	limit  int
+	period time.Duration
+}
+
+func (b tokenBucket) Allow(state *State, now time.Time) Decision {
+	var (
+		limit    = float64(b.limit)
+		interval = b.period / time.Duration(b.limit) // Time to refill one token.
+		tokens   = limit
+	)
+
+	if !state.Time.IsZero() {
+		tokens = math.Min(limit, state.Value+float64(now.Sub(state.Time))/float64(interval))
+	}
+
+	decision := Decision{Limit: b.limit}
+
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - tokens) * float64(interval))
+	}
+
+	state.Value = tokens
+	state.Time = now
+
+	decision.Remaining = int(tokens)
+	decision.Reset = time.Duration((limit - tokens) * float64(interval))
+
+	return decision
+}
+
+func (b tokenBucket) TTL() time.Duration {
+	return b.period
+}
+
+// SlidingWindow returns an [Algorithm] that allows up to limit requests in any window of the given duration. The
+// number of requests in the window is approximated by weighting the requests of the previous fixed window by its
+// overlap with the sliding one.
+//
+// If limit or window are not positive, SlidingWindow panics.
+func SlidingWindow(limit int, window time.Duration) Algorithm {
+	if limit <= 0 {
+		panic("limit should be positive")
+	}
+
+	if window <= 0 {
+		panic("window should be positive")
+	}
+
+	return slidingWindow{limit, window}
+}
+
+type slidingWindow struct {
+	limit  int
+	window time.Duration
+}
+
+func (w slidingWindow) Allow(state *State, now time.Time) Decision {
+	start := now.Truncate(w.window)
+
+	if !state.Time.Equal(start) {
+		if state.Time.Equal(start.Add(-w.window)) {
+			state.Previous = state.Value
+		} else {
+			state.Previous = 0
+		}
+
+		state.Value = 0
+		state.Time = start
+	}
+
+	var (
+		limit   = float64(w.limit)
+		elapsed = now.Sub(start)
+		weight  = 1 - float64(elapsed)/float64(w.window)
+		count   = state.Previous*weight + state.Value
+	)
+
+	decision := Decision{
+		Limit: w.limit,
+		Reset: w.window - elapsed,
+	}
+
+	if count+1 <= limit {
+		state.Value++
+		count++
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = w.retryAfter(state, elapsed)
+	}
+
+	decision.Remaining = int(math.Max(0, limit-count))
+
+	return decision
+}
+
+// retryAfter returns the time until the weighted count of requests is low enough for a request to be allowed.
+func (w slidingWindow) retryAfter(state *State, elapsed time.Duration) time.Duration {
+	available := float64(w.limit) - 1 - state.Value
+
+	if available < 0 || state.Previous == 0 {
+		// Wait for the next window, in which the current requests are weighted instead.
+		next := w.window - elapsed
+		if state.Value > float64(w.limit)-1 {
+			next += time.Duration((1 - (float64(w.limit)-1)/state.Value) * float64(w.window))
+		}
+
+		return next
+	}
+
+	return time.Duration((1-available/state.Previous)*float64(w.window)) - elapsed
+}
+
+func (w slidingWindow) TTL() time.Duration {
+	return 2 * w.window
+}
@@ -0,0 +1,135 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func TestAlgorithm_Allow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description       string
+		algorithm         ratelimit.Algorithm
+		requests          []time.Duration
+		expectedDecisions []ratelimit.Decision
+	}{
+		{
+			description: "TokenBucket_WhenBucketIsEmpty_ShouldReject",
+			algorithm:   ratelimit.TokenBucket(2, time.Minute),
+			requests:    []time.Duration{0, 0, 0},
+			expectedDecisions: []ratelimit.Decision{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: 30 * time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Minute},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: time.Minute, RetryAfter: 30 * time.Second},
+			},
+		},
+		{
+			description: "TokenBucket_WhenBucketIsRefilled_ShouldAllow",
+			algorithm:   ratelimit.TokenBucket(2, time.Minute),
+			requests:    []time.Duration{0, 0, 45 * time.Second, 45 * time.Second},
+			expectedDecisions: []ratelimit.Decision{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: 30 * time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Minute},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 45 * time.Second},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 15 * time.Second},
+			},
+		},
+		{
+			description: "SlidingWindow_WhenWindowIsFull_ShouldReject",
+			algorithm:   ratelimit.SlidingWindow(2, time.Minute),
+			requests:    []time.Duration{0, 30 * time.Second, 45 * time.Second},
+			expectedDecisions: []ratelimit.Decision{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Minute},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 30 * time.Second},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: 15 * time.Second, RetryAfter: 45 * time.Second},
+			},
+		},
+		{
+			description: "SlidingWindow_WhenPreviousWindowOverlaps_ShouldWeightIt",
+			algorithm:   ratelimit.SlidingWindow(2, time.Minute),
+			requests:    []time.Duration{0, 0, 75 * time.Second, 80 * time.Second, 90 * time.Second},
+			expectedDecisions: []ratelimit.Decision{
+				{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Minute},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Minute},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 15 * time.Second},
+				{Allowed: false, Limit: 2, Remaining: 0, Reset: 40 * time.Second, RetryAfter: 10 * time.Second},
+				{Allowed: true, Limit: 2, Remaining: 0, Reset: 30 * time.Second},
+			},
+		},
+		{
+			description: "SlidingWindow_WhenWindowsAreNotConsecutive_ShouldResetCount",
+			algorithm:   ratelimit.SlidingWindow(1, time.Minute),
+			requests:    []time.Duration{0, 150 * time.Second},
+			expectedDecisions: []ratelimit.Decision{
+				{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Minute},
+				{Allowed: true, Limit: 1, Remaining: 0, Reset: 30 * time.Second},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var state ratelimit.State
+
+			decisions := make([]ratelimit.Decision, 0, len(test.requests))
+
+			// Act
+			for _, request := range test.requests {
+				decisions = append(decisions, test.algorithm.Allow(&state, start.Add(request)))
+			}
+
+			// Assert
+			require.Equal(t, test.expectedDecisions, decisions)
+		})
+	}
+}
+
+func TestAlgorithm_WhenArgumentsAreInvalid_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description   string
+		function      func()
+		expectedPanic string
+	}{
+		{
+			description:   "TokenBucketLimit",
+			function:      func() { ratelimit.TokenBucket(0, time.Minute) },
+			expectedPanic: "limit should be positive",
+		},
+		{
+			description:   "TokenBucketPeriod",
+			function:      func() { ratelimit.TokenBucket(1, 0) },
+			expectedPanic: "period should be positive",
+		},
+		{
+			description:   "SlidingWindowLimit",
+			function:      func() { ratelimit.SlidingWindow(-1, time.Minute) },
+			expectedPanic: "limit should be positive",
+		},
+		{
+			description:   "SlidingWindowWindow",
+			function:      func() { ratelimit.SlidingWindow(1, -time.Minute) },
+			expectedPanic: "window should be positive",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.expectedPanic, test.function)
+		})
+	}
+}
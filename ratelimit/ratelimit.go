@@ -0,0 +1,191 @@
+// Package ratelimit contains a middleware that limits the rate of requests per client, protecting endpoints from
+// abuse.
+//
+// Requests are grouped by a key, such as the IP address of the client ([ByIP]) or its API key ([ByHeader]), and
+// allowed or rejected by an [Algorithm], such as [TokenBucket] or [SlidingWindow]. The states of the keys are kept in
+// a [Store], that is a [MemoryStore] by default:
+//
+//	r.Use(ratelimit.New(ratelimit.TokenBucket(100, time.Minute)))
+//
+//	r.POST("/login", Login, ratelimit.New(
+//		ratelimit.SlidingWindow(5, time.Minute),
+//		ratelimit.WithKey(ratelimit.ByHeader("X-Api-Key")),
+//	))
+//
+// Every response carries the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers. Rejected requests
+// are responded with 429 Too Many Requests and the Retry-After header.
+package ratelimit
+
+import (
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+)
+
+// defaultShards is the number of shards of the default store.
+const defaultShards = 32
+
+// KeyFunc returns the key that groups r with the requests that share its limit.
+type KeyFunc func(r *lit.Request) string
+
+// ByIP returns a [KeyFunc] that groups requests by the IP address of the client, taken from
+// [http.Request.RemoteAddr]. If the server is behind a proxy, use [ByFunc] to read the address set by it instead.
+func ByIP() KeyFunc {
+	return func(r *lit.Request) string {
+		host, _, err := net.SplitHostPort(r.Base().RemoteAddr)
+		if err != nil {
+			return r.Base().RemoteAddr
+		}
+
+		return host
+	}
+}
+
+// ByHeader returns a [KeyFunc] that groups requests by the value of the header key, such as an API key. Requests
+// without the header share the same limit.
+//
+// If key is empty, ByHeader panics.
+func ByHeader(key string) KeyFunc {
+	if key == "" {
+		panic("key should not be empty")
+	}
+
+	return func(r *lit.Request) string {
+		return r.Header().Get(key)
+	}
+}
+
+// ByFunc returns a [KeyFunc] that groups requests by the result of key.
+//
+// If key is nil, ByFunc panics.
+func ByFunc(key func(r *lit.Request) string) KeyFunc {
+	if key == nil {
+		panic("key should not be nil")
+	}
+
+	return key
+}
+
+// Option configures the middleware created by [New].
+type Option func(c *config)
+
+type config struct {
+	key      KeyFunc
+	store    Store
+	response lit.Response
+}
+
+// WithKey sets how requests are grouped. By default, they are grouped by IP address ([ByIP]).
+//
+// If key is nil, WithKey panics.
+func WithKey(key KeyFunc) Option {
+	if key == nil {
+		panic("key should not be nil")
+	}
+
+	return func(c *config) {
+		c.key = key
+	}
+}
+
+// WithStore sets the store of the states of the keys. Middlewares that share a store also share the limits of
+// the keys they have in common, so they should use the same algorithm. By default, each middleware has its own
+// [MemoryStore].
+//
+// If store is nil, WithStore panics.
+func WithStore(store Store) Option {
+	if store == nil {
+		panic("store should not be nil")
+	}
+
+	return func(c *config) {
+		c.store = store
+	}
+}
+
+// WithLimitedResponse sets the response to rejected requests. By default, it's a 429 Too Many Requests JSON
+// response. The rate limit headers are set in any case.
+//
+// If response is nil, WithLimitedResponse panics.
+func WithLimitedResponse(response lit.Response) Option {
+	if response == nil {
+		panic("response should not be nil")
+	}
+
+	return func(c *config) {
+		c.response = response
+	}
+}
+
+// New creates a middleware that limits the rate of requests with algorithm, configured by options.
+//
+// If the store fails, the error is logged and the request is allowed, so that the endpoints don't become
+// unavailable.
+//
+// If algorithm is nil, New panics.
+func New(algorithm Algorithm, options ...Option) lit.Middleware {
+	if algorithm == nil {
+		panic("algorithm should not be nil")
+	}
+
+	c := &config{
+		key:      ByIP(),
+		response: render.JSON(http.StatusTooManyRequests, "rate limit exceeded"),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.store == nil {
+		c.store = NewMemoryStore(defaultShards)
+	}
+
+	return func(h lit.Handler) lit.Handler {
+		return func(r *lit.Request) lit.Response {
+			decision, err := c.store.Take(r.Context(), c.key(r), algorithm, time.Now())
+			if err != nil {
+				log.Printf("rate limiting %s %s: %v", r.Method(), r.URL(), err)
+				return h(r)
+			}
+
+			if !decision.Allowed {
+				return withHeaders(c.response, decision)
+			}
+
+			res := h(r)
+			if res == nil {
+				return nil
+			}
+
+			return withHeaders(res, decision)
+		}
+	}
+}
+
+// withHeaders sets the rate limit headers of decision in the header of res.
+func withHeaders(res lit.Response, decision Decision) lit.Response {
+	return lit.ResponseFunc(func(w http.ResponseWriter) {
+		header := w.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("RateLimit-Reset", seconds(decision.Reset))
+
+		if !decision.Allowed {
+			header.Set("Retry-After", seconds(max(decision.RetryAfter, time.Second)))
+		}
+
+		res.Write(w)
+	})
+}
+
+// seconds formats d in seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,231 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/ratelimit"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+type failingStore struct{}
+
+func (s failingStore) Take(context.Context, string, ratelimit.Algorithm, time.Time) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("connection refused")
+}
+
+type request struct {
+	remoteAddress string
+	header        http.Header
+}
+
+type response struct {
+	statusCode int
+	header     http.Header
+	body       string
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description       string
+		options           []ratelimit.Option
+		requests          []request
+		expectedResponses []response
+	}{
+		{
+			description: "WhenLimitIsExceeded_ShouldRespondTooManyRequests",
+			requests: []request{
+				{remoteAddress: "192.0.2.1:1234"},
+				{remoteAddress: "192.0.2.1:5678"},
+			},
+			expectedResponses: []response{
+				{
+					statusCode: http.StatusOK,
+					header: http.Header{
+						"Content-Type":        {"application/json"},
+						"Ratelimit-Limit":     {"1"},
+						"Ratelimit-Remaining": {"0"},
+						"Ratelimit-Reset":     {"3600"},
+					},
+					body: `{"message":"ok"}`,
+				},
+				{
+					statusCode: http.StatusTooManyRequests,
+					header: http.Header{
+						"Content-Type":        {"application/json"},
+						"Ratelimit-Limit":     {"1"},
+						"Ratelimit-Remaining": {"0"},
+						"Ratelimit-Reset":     {"3600"},
+						"Retry-After":         {"3600"},
+					},
+					body: `{"message":"rate limit exceeded"}`,
+				},
+			},
+		},
+		{
+			description: "WhenIPsAreDifferent_ShouldNotShareLimit",
+			requests: []request{
+				{remoteAddress: "192.0.2.1:1234"},
+				{remoteAddress: "192.0.2.2:1234"},
+			},
+			expectedResponses: []response{
+				{statusCode: http.StatusOK},
+				{statusCode: http.StatusOK},
+			},
+		},
+		{
+			description: "WhenKeyIsHeader_ShouldGroupByIt",
+			options:     []ratelimit.Option{ratelimit.WithKey(ratelimit.ByHeader("X-Api-Key"))},
+			requests: []request{
+				{remoteAddress: "192.0.2.1:1234", header: http.Header{"X-Api-Key": {"a"}}},
+				{remoteAddress: "192.0.2.2:1234", header: http.Header{"X-Api-Key": {"a"}}},
+				{remoteAddress: "192.0.2.1:1234", header: http.Header{"X-Api-Key": {"b"}}},
+			},
+			expectedResponses: []response{
+				{statusCode: http.StatusOK},
+				{statusCode: http.StatusTooManyRequests},
+				{statusCode: http.StatusOK},
+			},
+		},
+		{
+			description: "WhenKeyIsFunc_ShouldGroupByIt",
+			options: []ratelimit.Option{
+				ratelimit.WithKey(ratelimit.ByFunc(func(r *lit.Request) string {
+					return "global"
+				})),
+			},
+			requests: []request{
+				{remoteAddress: "192.0.2.1:1234"},
+				{remoteAddress: "192.0.2.2:1234"},
+			},
+			expectedResponses: []response{
+				{statusCode: http.StatusOK},
+				{statusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			description: "WhenLimitedResponseIsSet_ShouldRespondIt",
+			options: []ratelimit.Option{
+				ratelimit.WithLimitedResponse(render.JSON(http.StatusServiceUnavailable, "slow down")),
+			},
+			requests: []request{
+				{remoteAddress: "192.0.2.1:1234"},
+				{remoteAddress: "192.0.2.1:1234"},
+			},
+			expectedResponses: []response{
+				{statusCode: http.StatusOK},
+				{statusCode: http.StatusServiceUnavailable, body: `{"message":"slow down"}`},
+			},
+		},
+		{
+			description: "WhenStoreFails_ShouldAllowRequests",
+			options:     []ratelimit.Option{ratelimit.WithStore(failingStore{})},
+			requests: []request{
+				{remoteAddress: "192.0.2.1:1234"},
+				{remoteAddress: "192.0.2.1:1234"},
+			},
+			expectedResponses: []response{
+				{statusCode: http.StatusOK, header: http.Header{"Content-Type": {"application/json"}}},
+				{statusCode: http.StatusOK, header: http.Header{"Content-Type": {"application/json"}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			router.GET("/users", func(r *lit.Request) lit.Response {
+				return render.OK("ok")
+			}, ratelimit.New(ratelimit.TokenBucket(1, time.Hour), test.options...))
+
+			for i, req := range test.requests {
+				request := httptest.NewRequest(http.MethodGet, "/users", nil)
+				request.RemoteAddr = req.remoteAddress
+
+				for key, values := range req.header {
+					request.Header[key] = values
+				}
+
+				recorder := httptest.NewRecorder()
+
+				// Act
+				router.ServeHTTP(recorder, request)
+
+				// Assert
+				expected := test.expectedResponses[i]
+
+				require.Equal(t, expected.statusCode, recorder.Code)
+
+				if expected.header != nil {
+					require.Equal(t, expected.header, recorder.Header())
+				}
+
+				if expected.body != "" {
+					require.Equal(t, expected.body, recorder.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOptions_WhenArgumentsAreInvalid_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description   string
+		function      func()
+		expectedPanic string
+	}{
+		{
+			description:   "NilAlgorithm",
+			function:      func() { ratelimit.New(nil) },
+			expectedPanic: "algorithm should not be nil",
+		},
+		{
+			description:   "NilKey",
+			function:      func() { ratelimit.WithKey(nil) },
+			expectedPanic: "key should not be nil",
+		},
+		{
+			description:   "NilStore",
+			function:      func() { ratelimit.WithStore(nil) },
+			expectedPanic: "store should not be nil",
+		},
+		{
+			description:   "NilLimitedResponse",
+			function:      func() { ratelimit.WithLimitedResponse(nil) },
+			expectedPanic: "response should not be nil",
+		},
+		{
+			description:   "EmptyHeader",
+			function:      func() { ratelimit.ByHeader("") },
+			expectedPanic: "key should not be empty",
+		},
+		{
+			description:   "NilFunc",
+			function:      func() { ratelimit.ByFunc(nil) },
+			expectedPanic: "key should not be nil",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.expectedPanic, test.function)
+		})
+	}
+}
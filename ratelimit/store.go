@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/maphash"
+	"sync"
+	"time"
+)
+
+// Store keeps the states of the keys. Implementations must be safe for concurrent use and apply algorithms
+// atomically for each key, so that concurrent requests are not allowed based on the same state.
+//
+// External backends, such as Redis, can implement Store by serializing [State].
+type Store interface {
+	// Take records a request for key made at now with algorithm, returning the decision.
+	Take(ctx context.Context, key string, algorithm Algorithm, now time.Time) (Decision, error)
+}
+
+// MemoryStore is a [Store] that keeps the states in memory. Keys are spread over shards, each one with its own lock,
+// to reduce contention. Expired states are removed while taking requests.
+type MemoryStore struct {
+	seed   maphash.Seed
+	shards []memoryShard
+}
+
+type memoryShard struct {
+	mutex     sync.Mutex
+	entries   map[string]*memoryEntry
+	nextSweep time.Time
+}
+
+type memoryEntry struct {
+	state  State
+	expiry time.Time
+}
+
+// NewMemoryStore creates a new [MemoryStore] instance with the given number of shards.
+//
+// If shards is not positive, NewMemoryStore panics.
+func NewMemoryStore(shards int) *MemoryStore {
+	if shards <= 0 {
+		panic("shards should be positive")
+	}
+
+	s := &MemoryStore{
+		seed:   maphash.MakeSeed(),
+		shards: make([]memoryShard, shards),
+	}
+
+	for i := range s.shards {
+		s.shards[i].entries = make(map[string]*memoryEntry)
+	}
+
+	return s
+}
+
+// Take records a request for key made at now with algorithm, returning the decision. It never returns an error.
+func (s *MemoryStore) Take(_ context.Context, key string, algorithm Algorithm, now time.Time) (Decision, error) {
+	shard := &s.shards[maphash.String(s.seed, key)%uint64(len(s.shards))]
+
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	ttl := algorithm.TTL()
+
+	if now.After(shard.nextSweep) {
+		shard.sweep(now)
+		shard.nextSweep = now.Add(ttl)
+	}
+
+	entry, ok := shard.entries[key]
+	if !ok || now.After(entry.expiry) {
+		entry = &memoryEntry{}
+		shard.entries[key] = entry
+	}
+
+	decision := algorithm.Allow(&entry.state, now)
+	entry.expiry = now.Add(ttl)
+
+	return decision, nil
+}
+
+// sweep removes the expired entries of s.
+func (s *memoryShard) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expiry) {
+			delete(s.entries, key)
+		}
+	}
+}
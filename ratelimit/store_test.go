@@ -0,0 +1,107 @@
+package ratelimit_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description     string
+		keys            []string
+		requests        []time.Duration
+		expectedAllowed []bool
+	}{
+		{
+			description:     "WhenKeysAreEqual_ShouldShareLimit",
+			keys:            []string{"a", "a"},
+			requests:        []time.Duration{0, 0},
+			expectedAllowed: []bool{true, false},
+		},
+		{
+			description:     "WhenKeysAreDifferent_ShouldNotShareLimit",
+			keys:            []string{"a", "b"},
+			requests:        []time.Duration{0, 0},
+			expectedAllowed: []bool{true, true},
+		},
+		{
+			description:     "WhenStateHasExpired_ShouldStartOver",
+			keys:            []string{"a", "b", "a"},
+			requests:        []time.Duration{0, 2 * time.Minute, 2 * time.Minute},
+			expectedAllowed: []bool{true, true, true},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				store     = ratelimit.NewMemoryStore(4)
+				algorithm = ratelimit.TokenBucket(1, time.Minute)
+				allowed   = make([]bool, 0, len(test.keys))
+			)
+
+			// Act
+			for i, key := range test.keys {
+				decision, err := store.Take(context.Background(), key, algorithm, start.Add(test.requests[i]))
+				require.NoError(t, err)
+
+				allowed = append(allowed, decision.Allowed)
+			}
+
+			// Assert
+			require.Equal(t, test.expectedAllowed, allowed)
+		})
+	}
+}
+
+func TestMemoryStore_Take_WhenRequestsAreConcurrent_ShouldAllowUpToLimit(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		store     = ratelimit.NewMemoryStore(8)
+		algorithm = ratelimit.SlidingWindow(100, time.Hour)
+		allowed   atomic.Int64
+		wg        sync.WaitGroup
+	)
+
+	// Act
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			decision, _ := store.Take(context.Background(), strconv.Itoa(i%2), algorithm, start)
+			if decision.Allowed {
+				allowed.Add(1)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Assert
+	require.EqualValues(t, 200, allowed.Load())
+}
+
+func TestNewMemoryStore_WhenShardsIsNotPositive_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "shards should be positive", func() {
+		ratelimit.NewMemoryStore(0)
+	})
+}
@@ -0,0 +1,127 @@
+package lit
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoverOption configures [RecoverWith].
+type RecoverOption func(*recoverConfig)
+
+type recoverConfig struct {
+	response func(r *Request, value any) Response
+	reporter func(value any, stack []byte, r *Request)
+}
+
+// WithPanicResponse sets the function that builds the response to requests whose handling panicked, given the
+// panic value. By default, a 500 Internal Server Error response is written, without exposing the value.
+//
+// If response is nil, WithPanicResponse panics.
+func WithPanicResponse(response func(r *Request, value any) Response) RecoverOption {
+	if response == nil {
+		panic("response should not be nil")
+	}
+
+	return func(c *recoverConfig) {
+		c.response = response
+	}
+}
+
+// WithPanicReporter sets the function that reports panics, given the panic value, the stack trace of the goroutine
+// that panicked and the request. It's called before the response is built. By default, the value and the stack
+// trace are logged with [log.Printf].
+//
+// If reporter is nil, WithPanicReporter panics.
+func WithPanicReporter(reporter func(value any, stack []byte, r *Request)) RecoverOption {
+	if reporter == nil {
+		panic("reporter should not be nil")
+	}
+
+	return func(c *recoverConfig) {
+		c.reporter = reporter
+	}
+}
+
+// RecoverWith is a middleware that recovers if the handler panics or if its response panics while being written,
+// reporting the panic and responding the request according to options (see [WithPanicResponse] and
+// [WithPanicReporter]).
+//
+// If the response panics before writing anything, the headers it has set are discarded. If it had already started to
+// be written when it panicked, the panic is reported and the connection is
+// aborted with [http.ErrAbortHandler], since the status code can't be changed anymore. Panics with
+// http.ErrAbortHandler are not recovered, so that net/http aborts the connection without logging it.
+func RecoverWith(options ...RecoverOption) Middleware {
+	config := &recoverConfig{
+		response: internalServerError,
+		reporter: logPanic,
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+
+	return func(h Handler) Handler {
+		return func(r *Request) (res Response) {
+			defer func() {
+				if value := recover(); value != nil {
+					res = config.recover(r, value, debug.Stack())
+				}
+			}()
+
+			response := h(r)
+
+			if response == nil {
+				return nil
+			}
+
+			return ResponseFunc(func(w http.ResponseWriter) {
+				recorder := NewRecorder(w)
+
+				defer func() {
+					value := recover()
+					if value == nil {
+						return
+					}
+
+					stack := debug.Stack()
+
+					if value != http.ErrAbortHandler && (recorder.written || recorder.ContentLength > 0) {
+						config.reporter(value, stack, r)
+						panic(http.ErrAbortHandler)
+					}
+
+					// The headers set by the failed response, such as Content-Length, don't describe this one.
+					clear(w.Header())
+
+					if response := config.recover(r, value, stack); response != nil {
+						response.Write(w)
+					}
+				}()
+
+				response.Write(recorder)
+			})
+		}
+	}
+}
+
+// recover reports value, returning the response to r. If value is http.ErrAbortHandler, recover panics with it.
+func (c *recoverConfig) recover(r *Request, value any, stack []byte) Response {
+	if value == http.ErrAbortHandler {
+		panic(value)
+	}
+
+	c.reporter(value, stack, r)
+
+	return c.response(r, value)
+}
+
+func internalServerError(_ *Request, _ any) Response {
+	return ResponseFunc(func(w http.ResponseWriter) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	})
+}
+
+func logPanic(value any, stack []byte, _ *Request) {
+	log.Printf("recovering from a panic: %v\n%s", value, stack)
+}
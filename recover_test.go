@@ -0,0 +1,203 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverWith(t *testing.T) {
+	t.Parallel()
+
+	incidentResponse := lit.WithPanicResponse(func(r *lit.Request, value any) lit.Response {
+		return render.InternalServerError(map[string]string{"incident": "42"})
+	})
+
+	tests := []struct {
+		description        string
+		options            []lit.RecoverOption
+		handler            lit.Handler
+		expectedStatusCode int
+		expectedHeader     http.Header
+		expectedBody       string
+		expectedValue      any
+	}{
+		{
+			description: "WhenHandlerDoesNotPanic_ShouldRespondItsResponse",
+			handler: func(r *lit.Request) lit.Response {
+				return render.OK("ok")
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"message":"ok"}`,
+		},
+		{
+			description: "WhenHandlerPanics_ShouldRespondInternalServerErrorWithoutValue",
+			handler: func(r *lit.Request) lit.Response {
+				panic("scary!")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "Internal Server Error\n",
+			expectedValue:      "scary!",
+		},
+		{
+			description: "WhenHandlerPanicsAndResponseIsSet_ShouldRespondIt",
+			options:     []lit.RecoverOption{incidentResponse},
+			handler: func(r *lit.Request) lit.Response {
+				panic("scary!")
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"incident":"42"}`,
+			expectedValue:      "scary!",
+		},
+		{
+			description: "WhenResponsePanicsBeforeWriting_ShouldRespondInternalServerError",
+			options:     []lit.RecoverOption{incidentResponse},
+			handler: func(r *lit.Request) lit.Response {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					w.Header().Set("Content-Type", "text/plain")
+					w.Header().Set("Content-Length", "1024")
+					w.Header().Set("Content-Encoding", "gzip")
+					panic("scary!")
+				})
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedHeader:     http.Header{"Content-Type": {"application/json"}},
+			expectedBody:       `{"incident":"42"}`,
+			expectedValue:      "scary!",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+
+			var (
+				reportedValue   any
+				reportedStack   []byte
+				reportedRequest *lit.Request
+			)
+
+			reporter := lit.WithPanicReporter(func(value any, stack []byte, r *lit.Request) {
+				reportedValue, reportedStack, reportedRequest = value, stack, r
+			})
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			response := lit.RecoverWith(append(test.options, reporter)...)(test.handler)(r)
+			response.Write(recorder)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedValue, reportedValue)
+
+			if test.expectedHeader != nil {
+				require.Equal(t, test.expectedHeader, recorder.Header())
+			}
+
+			if test.expectedValue != nil {
+				require.NotEmpty(t, reportedStack)
+				require.Same(t, r, reportedRequest)
+			}
+		})
+	}
+}
+
+func TestRecoverWith_WhenResponsePanicsAfterWriting_ShouldAbortHandler(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	var reportedValue any
+
+	middleware := lit.RecoverWith(lit.WithPanicReporter(func(value any, _ []byte, _ *lit.Request) {
+		reportedValue = value
+	}))
+
+	handler := func(r *lit.Request) lit.Response {
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			w.Write([]byte("partial"))
+			panic("scary!")
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+
+	// Act
+	response := middleware(handler)(r)
+
+	// Assert
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		response.Write(recorder)
+	})
+	require.Equal(t, "scary!", reportedValue)
+	require.Equal(t, "partial", recorder.Body.String())
+}
+
+func TestRecoverWith_WhenPanicValueIsErrAbortHandler_ShouldPanicWithoutReporting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		handler     lit.Handler
+	}{
+		{
+			description: "InHandler",
+			handler: func(r *lit.Request) lit.Response {
+				panic(http.ErrAbortHandler)
+			},
+		},
+		{
+			description: "InResponse",
+			handler: func(r *lit.Request) lit.Response {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					panic(http.ErrAbortHandler)
+				})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+
+			reported := false
+
+			middleware := lit.RecoverWith(lit.WithPanicReporter(func(any, []byte, *lit.Request) {
+				reported = true
+			}))
+
+			// Act
+			// Assert
+			require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+				middleware(test.handler)(r).Write(httptest.NewRecorder())
+			})
+			require.False(t, reported)
+		})
+	}
+}
+
+func TestRecoverWith_WhenOptionsAreNil_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "response should not be nil", func() {
+		lit.WithPanicResponse(nil)
+	})
+	require.PanicsWithValue(t, "reporter should not be nil", func() {
+		lit.WithPanicReporter(nil)
+	})
+}
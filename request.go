@@ -3,14 +3,21 @@ package lit
 import (
 	"context"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 )
 
 // Request is the input of a [Handler].
+//
+// Requests dispatched by a [Router] are pooled: once the response is written, the request is reset and reused by
+// subsequent requests. Therefore, neither the request nor its URI parameters should be retained after the handler
+// returns. If they are needed afterward (in a goroutine started by the handler, for instance), use a copy created by
+// [*Request.Clone].
 type Request struct {
 	base       *http.Request
 	parameters map[string]string
+	route      RouteInfo
 }
 
 // NewEmptyRequest creates a new [Request] instance.
@@ -29,8 +36,8 @@ func NewRequest(request *http.Request) *Request {
 	}
 
 	return &Request{
-		request,
-		make(map[string]string),
+		base:       request,
+		parameters: make(map[string]string),
 	}
 }
 
@@ -60,6 +67,43 @@ func (r *Request) WithURIParameters(parameters map[string]string) *Request {
 	return r
 }
 
+// WithRoute sets the description of the route matched by this request.
+func (r *Request) WithRoute(route RouteInfo) *Request {
+	r.route = route
+	return r
+}
+
+// Route returns the description of the route matched by this request, including its pattern, method and metadata.
+// If the request has not matched a route (when handled by the Not Found handler, for instance), Route returns the
+// zero value.
+//
+// The metadata of the route is shared by all of its requests and should not be modified.
+func (r *Request) Route() RouteInfo {
+	return r.route
+}
+
+// Clone returns a copy of this request that can be retained after the handler returns. Its URI parameters are
+// copied, while its base request is shared.
+func (r *Request) Clone() *Request {
+	return &Request{
+		base:       r.base,
+		parameters: maps.Clone(r.parameters),
+		route:      r.route,
+	}
+}
+
+// reset clears this request, so that it can be reused.
+func (r *Request) reset() {
+	if r.parameters == nil {
+		r.parameters = make(map[string]string)
+	} else {
+		clear(r.parameters)
+	}
+
+	r.base = nil
+	r.route = RouteInfo{}
+}
+
 // URIParameters returns this request's URL path parameters and their values. It can be nil, meaning the
 // handler expects no parameters.
 //
@@ -187,3 +187,41 @@ func TestRequest_WithURIParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestRequest_WithRoute(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		r     = lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+		route = lit.RouteInfo{
+			Method:   http.MethodGet,
+			Pattern:  "/users",
+			Metadata: map[string]any{"cache": true},
+		}
+	)
+
+	// Act
+	r.WithRoute(route)
+
+	// Assert
+	require.Equal(t, route, r.Route())
+}
+
+func TestRequest_Clone(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users/1", nil)).
+		WithURIParameters(map[string]string{"user_id": "1"}).
+		WithRoute(lit.RouteInfo{Method: http.MethodGet, Pattern: "/users/:user_id"})
+
+	// Act
+	clone := r.Clone()
+	r.URIParameters()["user_id"] = "2"
+
+	// Assert
+	require.Equal(t, r.Base(), clone.Base())
+	require.Equal(t, r.Route(), clone.Route())
+	require.Equal(t, map[string]string{"user_id": "1"}, clone.URIParameters())
+}
@@ -0,0 +1,296 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRouteNotFound is returned when no route is registered with a given name.
+	ErrRouteNotFound = errors.New("route not found")
+
+	// ErrMissingURIParameter is returned when a URI parameter required to build a route URL is not provided.
+	ErrMissingURIParameter = errors.New("missing URI parameter")
+
+	// ErrInvalidURIParameter is returned when a URI parameter provided to build a route URL does not satisfy the
+	// constraint declared for it.
+	ErrInvalidURIParameter = errors.New("invalid URI parameter")
+)
+
+// Route is a handler registered in a [Router] for a method and a path pattern.
+//
+// Routes are returned by the registration methods, such as [*Router.Handle], and can be further configured.
+type Route struct {
+	router          *Router
+	host            *host
+	method          string
+	pattern         string
+	path            string
+	constraints     []parameterConstraint
+	name            string
+	handler         Handler
+	handlerName     string
+	middlewares     []Middleware
+	version         *APIVersion
+	versioned       *versionedRoute
+	metadata        map[string]any
+	timeout         time.Duration
+	timeoutResponse Response
+	chain           Handler
+	info            RouteInfo
+}
+
+// RouteInfo describes a registered [Route].
+type RouteInfo struct {
+	// Host pattern of the route. It can be empty, meaning the route matches any host not matched by a host pattern.
+	Host string
+
+	// Method of the route.
+	Method string
+
+	// Path pattern of the route, as registered.
+	Pattern string
+
+	// Name of the route. It can be empty, meaning the route has not been named.
+	Name string
+
+	// Number of local middlewares of the route, including the ones inherited from groups and API versions.
+	LocalMiddlewares int
+
+	// Name of the function (or type, if it is not a function) that handles the route.
+	Handler string
+
+	// Metadata attached to the route with [*Route.WithMetadata]. It can be nil, meaning the route has no metadata.
+	Metadata map[string]any
+}
+
+// WithName names this route, so that its URL can be built with [*Router.URL].
+//
+// If name is empty, it is already used by another route or the router has already started serving requests,
+// WithName panics.
+func (r *Route) WithName(name string) *Route {
+	if name == "" {
+		panic("name should not be empty")
+	}
+
+	r.router.checkNotFrozen()
+
+	if route, ok := r.router.names[name]; ok && route != r {
+		panic("a route is already registered with name '" + name + "'")
+	}
+
+	delete(r.router.names, r.name)
+
+	r.name = name
+	r.router.names[name] = r
+
+	return r
+}
+
+// WithMetadata attaches value to this route under key, replacing any value previously attached under the same key.
+// Metadata is available to handlers and middlewares through [*Request.Route], so that facts about the route, such as
+// the scopes it requires, can be declared along with it:
+//
+//	r.POST("/orders", CreateOrder).WithMetadata("scopes", []string{"orders:write"})
+//
+// If key is empty or the router has already started serving requests, WithMetadata panics.
+func (r *Route) WithMetadata(key string, value any) *Route {
+	if key == "" {
+		panic("key should not be empty")
+	}
+
+	r.router.checkNotFrozen()
+
+	if r.metadata == nil {
+		r.metadata = make(map[string]any)
+	}
+
+	r.metadata[key] = value
+
+	return r
+}
+
+// WithTimeout bounds how long the handler of this route, including its local middlewares, may run. It's equivalent
+// to registering [Timeout] as the first local middleware of the route.
+//
+// If d is not positive or the router has already started serving requests, WithTimeout panics.
+func (r *Route) WithTimeout(d time.Duration) *Route {
+	if d <= 0 {
+		panic("d should be positive")
+	}
+
+	r.router.checkNotFrozen()
+
+	r.timeout = d
+
+	return r
+}
+
+// WithTimeoutResponse sets the response written when the handler of this route does not return within the duration
+// set by [*Route.WithTimeout], as in [TimeoutWith]. If response is nil, a 503 Service Unavailable response is written.
+//
+// If the router has already started serving requests, WithTimeoutResponse panics.
+func (r *Route) WithTimeoutResponse(response Response) *Route {
+	r.router.checkNotFrozen()
+
+	r.timeoutResponse = response
+
+	return r
+}
+
+// Info returns the description of this route.
+func (r *Route) Info() RouteInfo {
+	var hostPattern string
+	if r.host != nil {
+		hostPattern = r.host.pattern
+	}
+
+	described := r
+	if r.versioned != nil {
+		described = r.versioned.described()
+	}
+
+	return RouteInfo{
+		Host:             hostPattern,
+		Method:           r.method,
+		Pattern:          r.pattern,
+		Name:             r.name,
+		LocalMiddlewares: len(described.localMiddlewares()),
+		Handler:          described.handlerName,
+		Metadata:         maps.Clone(r.metadata),
+	}
+}
+
+// localMiddlewares returns the middlewares of the API version of this route, if any, followed by its own.
+func (r *Route) localMiddlewares() []Middleware {
+	if r.version == nil {
+		return r.middlewares
+	}
+
+	return append(slices.Clone(r.version.middlewares), r.middlewares...)
+}
+
+// Routes returns the description of all registered routes, in order of registration.
+func (r *Router) Routes() []RouteInfo {
+	routes := make([]RouteInfo, len(r.routes))
+	for i, route := range r.routes {
+		routes[i] = route.Info()
+	}
+
+	return routes
+}
+
+// URL builds the URL path of the route registered with name, replacing its URI parameters (both ":param" and
+// "*catchAll" ones) with the escaped values from parameters and appending query, if not empty. The keys from
+// parameters don't start with the ":" or "*" prefixes.
+//
+// For example, given a route named "user_book" with pattern "/users/:user_id/books/:book_id",
+//
+//	URL("user_book", map[string]string{"user_id": "1", "book_id": "2"}, url.Values{"fields": {"title"}})
+//
+// returns "/users/1/books/2?fields=title".
+//
+// If there is no route named name, URL returns [ErrRouteNotFound]. If a URI parameter of the route is not present in
+// parameters, URL returns [ErrMissingURIParameter]. If a value does not satisfy the constraint of its parameter (see
+// [Constraint]), URL returns [ErrInvalidURIParameter].
+func (r *Router) URL(name string, parameters map[string]string, query url.Values) (string, error) {
+	route, ok := r.names[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrRouteNotFound, name)
+	}
+
+	path, err := buildPath(route.path, parameters)
+	if err != nil {
+		return "", err
+	}
+
+	for _, constraint := range route.constraints {
+		value := parameters[constraint.parameter]
+
+		// Catch-all values are matched with a leading slash.
+		if strings.HasSuffix(route.path, "*"+constraint.parameter) {
+			value = "/" + strings.TrimPrefix(value, "/")
+		}
+
+		if !constraint.satisfies(value) {
+			return "", fmt.Errorf("%w: %s", ErrInvalidURIParameter, constraint.parameter)
+		}
+	}
+
+	if len(query) > 0 {
+		return path + "?" + query.Encode(), nil
+	}
+
+	return path, nil
+}
+
+func handlerName(handler any) string {
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", handler)
+	}
+
+	if function := runtime.FuncForPC(value.Pointer()); function != nil {
+		return function.Name()
+	}
+
+	return fmt.Sprintf("%T", handler)
+}
+
+func buildPath(pattern string, parameters map[string]string) (string, error) {
+	path := &strings.Builder{}
+
+	for len(pattern) > 0 {
+		i := strings.IndexAny(pattern, ":*")
+		if i < 0 {
+			path.WriteString(pattern)
+			break
+		}
+
+		path.WriteString(pattern[:i])
+
+		var (
+			wildcard = pattern[i]
+			end      = strings.IndexByte(pattern[i:], '/')
+		)
+
+		if end < 0 {
+			end = len(pattern)
+		} else {
+			end += i
+		}
+
+		key := pattern[i+1 : end]
+
+		value, ok := parameters[key]
+		if !ok || (wildcard == ':' && value == "") {
+			return "", fmt.Errorf("%w: %s", ErrMissingURIParameter, key)
+		}
+
+		if wildcard == '*' {
+			path.WriteString(escapeSegments(strings.TrimPrefix(value, "/")))
+		} else {
+			path.WriteString(url.PathEscape(value))
+		}
+
+		pattern = pattern[end:]
+	}
+
+	return path.String(), nil
+}
+
+func escapeSegments(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
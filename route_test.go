@@ -0,0 +1,358 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute_WithName(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response { return nil }
+
+	tests := []struct {
+		description string
+		setupRouter func(*lit.Router)
+		name        string
+		panicValue  string
+	}{
+		{
+			description: "WhenNameIsEmpty_ShouldPanic",
+			name:        "",
+			panicValue:  "name should not be empty",
+		},
+		{
+			description: "WhenNameIsUsedByAnotherRoute_ShouldPanic",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/books", handler).WithName("users")
+			},
+			name:       "users",
+			panicValue: "a route is already registered with name 'users'",
+		},
+		{
+			description: "WhenNameIsNotUsed_ShouldNotPanic",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/books", handler).WithName("books")
+			},
+			name: "users",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			if test.setupRouter != nil {
+				test.setupRouter(router)
+			}
+
+			route := router.GET("/users", handler)
+
+			// Act
+			// Assert
+			if test.panicValue != "" {
+				require.PanicsWithValue(t, test.panicValue, func() {
+					route.WithName(test.name)
+				})
+
+				return
+			}
+
+			require.NotPanics(t, func() {
+				route.WithName(test.name)
+			})
+		})
+	}
+}
+
+func TestRoute_WithMetadata(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response { return nil }
+
+	tests := []struct {
+		description      string
+		metadata         [][2]any
+		expectedMetadata map[string]any
+		panicValue       string
+	}{
+		{
+			description: "WhenKeyIsEmpty_ShouldPanic",
+			metadata:    [][2]any{{"", "value"}},
+			panicValue:  "key should not be empty",
+		},
+		{
+			description: "WhenNoMetadataIsAttached_ShouldBeNil",
+		},
+		{
+			description:      "WhenMetadataIsAttached_ShouldDescribeIt",
+			metadata:         [][2]any{{"scopes", []string{"orders:write"}}, {"cache", true}},
+			expectedMetadata: map[string]any{"scopes": []string{"orders:write"}, "cache": true},
+		},
+		{
+			description:      "WhenKeyIsRepeated_ShouldReplaceValue",
+			metadata:         [][2]any{{"cache", true}, {"cache", false}},
+			expectedMetadata: map[string]any{"cache": false},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			route := lit.NewRouter().GET("/orders", handler)
+
+			attach := func() {
+				for _, metadata := range test.metadata {
+					route.WithMetadata(metadata[0].(string), metadata[1])
+				}
+			}
+
+			// Act
+			// Assert
+			if test.panicValue != "" {
+				require.PanicsWithValue(t, test.panicValue, attach)
+				return
+			}
+
+			attach()
+			require.Equal(t, test.expectedMetadata, route.Info().Metadata)
+		})
+	}
+}
+
+func TestRequest_Route(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description   string
+		method        string
+		path          string
+		expectedRoute lit.RouteInfo
+	}{
+		{
+			description: "WhenRequestMatchesRoute_ShouldDescribeIt",
+			method:      http.MethodPost,
+			path:        "/orders/1",
+			expectedRoute: lit.RouteInfo{
+				Method:   http.MethodPost,
+				Pattern:  "/orders/:id<int>",
+				Name:     "update_order",
+				Handler:  "github.com/jvcoutinho/lit_test.listUsers",
+				Metadata: map[string]any{"scopes": []string{"orders:write"}},
+			},
+		},
+		{
+			description:   "WhenRequestDoesNotMatchRoute_ShouldBeZero",
+			method:        http.MethodGet,
+			path:          "/users",
+			expectedRoute: lit.RouteInfo{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				router = lit.NewRouter()
+				got    lit.RouteInfo
+			)
+
+			router.Use(func(h lit.Handler) lit.Handler {
+				return func(r *lit.Request) lit.Response {
+					got = r.Route()
+					return h(r)
+				}
+			})
+
+			router.POST("/orders/:id<int>", listUsers).
+				WithName("update_order").
+				WithMetadata("scopes", []string{"orders:write"})
+
+			// Act
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, test.path, nil))
+
+			// Assert
+			require.Equal(t, test.expectedRoute, got)
+		})
+	}
+}
+
+func TestRouter_URL(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response { return nil }
+
+	tests := []struct {
+		description   string
+		pattern       string
+		name          string
+		parameters    map[string]string
+		query         url.Values
+		expectedURL   string
+		expectedError string
+	}{
+		{
+			description:   "WhenRouteIsNotRegistered_ShouldReturnError",
+			pattern:       "/users",
+			name:          "books",
+			expectedError: "route not found: books",
+		},
+		{
+			description: "WhenRouteHasNoParameters_ShouldReturnPattern",
+			pattern:     "/users",
+			name:        "route",
+			expectedURL: "/users",
+		},
+		{
+			description: "WhenRouteHasParameters_ShouldReplaceThem",
+			pattern:     "/users/:user_id/books/:book_id",
+			name:        "route",
+			parameters:  map[string]string{"user_id": "1", "book_id": "2"},
+			expectedURL: "/users/1/books/2",
+		},
+		{
+			description: "WhenParameterValuesHaveReservedCharacters_ShouldEscapeThem",
+			pattern:     "/users/:name",
+			name:        "route",
+			parameters:  map[string]string{"name": "John Doe/?"},
+			expectedURL: "/users/John%20Doe%2F%3F",
+		},
+		{
+			description: "WhenRouteHasConstraints_AndParametersSatisfyThem_ShouldReplaceThem",
+			pattern:     "/users/:user_id<int>/books/:book_id<regex:[0-9]+>",
+			name:        "route",
+			parameters:  map[string]string{"user_id": "1", "book_id": "2"},
+			expectedURL: "/users/1/books/2",
+		},
+		{
+			description:   "WhenParameterDoesNotSatisfyConstraint_ShouldReturnError",
+			pattern:       "/users/:user_id<int>/books/:book_id<regex:[0-9]+>",
+			name:          "route",
+			parameters:    map[string]string{"user_id": "1", "book_id": "abc"},
+			expectedError: "invalid URI parameter: book_id",
+		},
+		{
+			description:   "WhenParameterIsMissing_ShouldReturnError",
+			pattern:       "/users/:user_id/books/:book_id",
+			name:          "route",
+			parameters:    map[string]string{"user_id": "1"},
+			expectedError: "missing URI parameter: book_id",
+		},
+		{
+			description:   "WhenParameterIsEmpty_ShouldReturnError",
+			pattern:       "/users/:user_id",
+			name:          "route",
+			parameters:    map[string]string{"user_id": ""},
+			expectedError: "missing URI parameter: user_id",
+		},
+		{
+			description: "WhenRouteHasCatchAllParameter_ShouldReplaceItKeepingSlashes",
+			pattern:     "/static/*filepath",
+			name:        "route",
+			parameters:  map[string]string{"filepath": "/css/main file.css"},
+			expectedURL: "/static/css/main%20file.css",
+		},
+		{
+			description:   "WhenCatchAllParameterIsMissing_ShouldReturnError",
+			pattern:       "/static/*filepath",
+			name:          "route",
+			expectedError: "missing URI parameter: filepath",
+		},
+		{
+			description: "WhenQueryIsNotEmpty_ShouldAppendIt",
+			pattern:     "/users/:user_id",
+			name:        "route",
+			parameters:  map[string]string{"user_id": "1"},
+			query:       url.Values{"fields": {"name", "email"}},
+			expectedURL: "/users/1?fields=name&fields=email",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			router.GET(test.pattern, handler).WithName("route")
+
+			// Act
+			got, err := router.URL(test.name, test.parameters, test.query)
+
+			// Assert
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expectedURL, got)
+		})
+	}
+}
+
+func listUsers(_ *lit.Request) lit.Response { return nil }
+
+func TestRouter_Routes(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		router     = lit.NewRouter()
+		middleware = func(h lit.Handler) lit.Handler { return h }
+	)
+
+	router.Use(middleware)
+	router.GET("/users", listUsers).WithName("list_users")
+	router.Group("/api", middleware).POST("/users/:id", listUsers, middleware)
+	router.Mount("/debug", lit.NewRouter())
+
+	// Act
+	routes := router.Routes()
+
+	// Assert
+	require.Len(t, routes, 28)
+	require.Equal(t, lit.RouteInfo{
+		Method:           http.MethodGet,
+		Pattern:          "/users",
+		Name:             "list_users",
+		LocalMiddlewares: 0,
+		Handler:          "github.com/jvcoutinho/lit_test.listUsers",
+	}, routes[0])
+	require.Equal(t, lit.RouteInfo{
+		Method:           http.MethodPost,
+		Pattern:          "/api/users/:id",
+		Name:             "",
+		LocalMiddlewares: 2,
+		Handler:          "github.com/jvcoutinho/lit_test.listUsers",
+	}, routes[1])
+	require.Equal(t, lit.RouteInfo{
+		Method:           http.MethodGet,
+		Pattern:          "/debug",
+		Name:             "",
+		LocalMiddlewares: 0,
+		Handler:          "*lit.Router",
+	}, routes[2])
+	require.Equal(t, lit.RouteInfo{
+		Method:           http.MethodGet,
+		Pattern:          "/debug/*mounted_path",
+		Name:             "",
+		LocalMiddlewares: 0,
+		Handler:          "*lit.Router",
+	}, routes[3])
+}
@@ -1,9 +1,11 @@
 package lit
 
 import (
+	"maps"
 	"net/http"
 	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -26,19 +28,83 @@ func (h Handler) Base() http.HandlerFunc {
 type Middleware func(h Handler) Handler
 
 // Router manages, listens and serves HTTP requests.
+//
+// A Router can be freely configured until it serves its first request. From then on, it is considered frozen and
+// any attempt to modify it (registering routes or middlewares, for instance) panics.
 type Router struct {
-	router      *httprouter.Router
-	requestPool sync.Pool
-	middlewares []Middleware
+	router              *httprouter.Router
+	requestPool         sync.Pool
+	middlewares         []Middleware
+	routes              []*Route
+	names               map[string]*Route
+	notFound            Handler
+	methodNotAllowed    Handler
+	options             Handler
+	constraintMismatch  Handler
+	errorHandler        func(r *Request, err error) Response
+	constraints         map[string]Constraint
+	hosts               map[string]*host
+	wildcardHosts       []*host
+	fallbackMiddlewares bool
+	automaticHEAD       bool
+	heads               map[headKey]*headRoute
+	versionings         []*Versioning
+	mounts              []*Route
+	mountTrees          map[*httprouter.Router]*httprouter.Router
+	paths               pathPolicy
+	freezeOnce          sync.Once
+	freezePanic         any
+	frozen              atomic.Bool
+}
+
+// RouterOption configures a [Router].
+type RouterOption func(r *Router)
+
+// WithFallbackMiddlewares sets whether global middlewares are applied to the fallback handlers, that is, the
+// handlers registered with [*Router.HandleNotFound], [*Router.HandleMethodNotAllowed], [*Router.HandleOPTIONS] and
+// [*Router.HandleConstraintMismatch] (or their default implementations). By default, they are.
+func WithFallbackMiddlewares(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.fallbackMiddlewares = enabled
+	}
+}
+
+// WithAutomaticHEAD sets whether HEAD requests are answered by the handlers of GET routes that don't have a HEAD
+// route registered for the same path. In this case, the response body written by the GET handler is discarded, but
+// the Content-Length header still reports its size. By default, they are not.
+//
+// The HEAD route of a GET route is registered along with it, so a HEAD route that conflicts with it makes the
+// registration panic.
+func WithAutomaticHEAD(enabled bool) RouterOption {
+	return func(r *Router) {
+		r.automaticHEAD = enabled
+	}
 }
 
-// NewRouter creates a new [Router] instance.
-func NewRouter() *Router {
-	return &Router{
-		httprouter.New(),
-		sync.Pool{New: func() any { return NewEmptyRequest() }},
-		make([]Middleware, 0),
+// NewRouter creates a new [Router] instance, configured by options.
+func NewRouter(options ...RouterOption) *Router {
+	r := &Router{
+		router:              httprouter.New(),
+		requestPool:         sync.Pool{New: func() any { return NewEmptyRequest() }},
+		middlewares:         make([]Middleware, 0),
+		routes:              make([]*Route, 0),
+		names:               make(map[string]*Route),
+		notFound:            notFound,
+		methodNotAllowed:    methodNotAllowed,
+		options:             allowOPTIONS,
+		errorHandler:        ErrorResponse,
+		constraints:         maps.Clone(defaultConstraints),
+		hosts:               make(map[string]*host),
+		heads:               make(map[headKey]*headRoute),
+		fallbackMiddlewares: true,
+		paths:               pathPolicy{redirectTrailingSlash: true, redirectFixedPath: true},
+	}
+
+	for _, option := range options {
+		option(r)
 	}
+
+	return r
 }
 
 // Use registers m as a global middleware. They run in every request.
@@ -49,14 +115,16 @@ func NewRouter() *Router {
 //
 //	(G1(G2(L1(L2(h)))))(r)
 //
-// Global middlewares should be set before any handler is registered.
+// Global middlewares are applied to every route, including the ones registered before the call to Use.
 //
-// If m is nil, Use panics.
+// If m is nil or r has already started serving requests, Use panics.
 func (r *Router) Use(m Middleware) {
 	if m == nil {
 		panic("m should not be nil")
 	}
 
+	r.checkNotFrozen()
+
 	r.middlewares = append(r.middlewares, m)
 }
 
@@ -68,8 +136,21 @@ func (r *Router) Use(m Middleware) {
 //
 //	(G1(G2(L1(L2(handler)))))(r)
 //
-// If path does not contain a leading slash, method is empty, handler is nil or a middleware is nil, Handle panics.
-func (r *Router) Handle(path string, method string, handler Handler, middlewares ...Middleware) {
+// URI parameters in path can declare constraints, such as in "/users/:id<int>". Requests whose parameters don't
+// satisfy them are handled by the handler set in [*Router.HandleConstraintMismatch]. Check [Constraint].
+//
+// Handle returns the registered [*Route], that can be further configured.
+//
+// Method is case-sensitive and can be any valid method name, including extended ones such as [MethodQuery] and
+// [MethodPropfind].
+//
+// If path does not contain a leading slash, method is empty or not a valid token, handler is nil, a middleware is
+// nil, a constraint is unknown or invalid or r has already started serving requests, Handle panics.
+func (r *Router) Handle(path string, method string, handler Handler, middlewares ...Middleware) *Route {
+	return r.handle(nil, path, method, handler, middlewares)
+}
+
+func (r *Router) handle(host *host, path string, method string, handler Handler, middlewares []Middleware) *Route {
 	if handler == nil {
 		panic("handler should not be nil")
 	}
@@ -78,40 +159,85 @@ func (r *Router) Handle(path string, method string, handler Handler, middlewares
 		panic("method should not be empty")
 	}
 
+	if !isToken(method) {
+		panic("method '" + method + "' should be a valid token")
+	}
+
 	if slices.ContainsFunc(middlewares, func(m Middleware) bool { return m == nil }) {
 		panic("middlewares should not be nil")
 	}
 
-	handler = transform(transform(handler, middlewares), r.middlewares)
+	r.checkNotFrozen()
+
+	routerPath, constraints := r.parsePattern(path)
+
+	route := &Route{
+		router:      r,
+		host:        host,
+		method:      method,
+		pattern:     path,
+		path:        routerPath,
+		constraints: constraints,
+		handler:     handler,
+		handlerName: handlerName(handler),
+		middlewares: slices.Clone(middlewares),
+	}
+
+	if r.automaticHEAD && (method == http.MethodGet || method == http.MethodHead) {
+		r.handleHEAD(route)
+	} else {
+		r.register(r.tree(host), method, routerPath, r.dispatch(route))
+	}
+
+	r.routes = append(r.routes, route)
+
+	return route
+}
 
-	r.router.Handle(method, path, func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+func (r *Router) dispatch(route *Route) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
 		request := r.requestPool.Get().(*Request).
-			WithRequest(req)
+			WithRequest(req).
+			WithRoute(route.info)
 
 		for _, param := range params {
 			request.parameters[param.Key] = param.Value
 		}
 
+		if route.host != nil {
+			route.host.match(normalizeHostname(req.Host), request.parameters)
+		}
+
+		handler := route.chain
+		if !satisfiesConstraints(route.constraints, request.parameters) {
+			handler = r.constraintMismatch
+		}
+
 		response := handler(request)
 
 		if response != nil {
 			response.Write(w)
 		}
 
+		request.reset()
 		r.requestPool.Put(request)
-	})
+	}
 }
 
 // HandleNotFound registers handler to be called when no matching route is found. By default, Lit uses a
 // wrapped http.NotFound.
 //
-// If handler is nil, HandleNotFound panics.
+// Unless disabled by [WithFallbackMiddlewares], global middlewares are applied to handler.
+//
+// If handler is nil or r has already started serving requests, HandleNotFound panics.
 func (r *Router) HandleNotFound(handler Handler) {
 	if handler == nil {
 		panic("handler should not be nil")
 	}
 
-	r.router.NotFound = handler.Base()
+	r.checkNotFrozen()
+
+	r.notFound = handler
 }
 
 // HandleOPTIONS registers handler to be called when the request method is OPTIONS. By default, Lit sets the
@@ -119,32 +245,49 @@ func (r *Router) HandleNotFound(handler Handler) {
 //
 // Useful to support preflight CORS requests, for instance.
 //
+// Unless disabled by [WithFallbackMiddlewares], global middlewares are applied to handler.
+//
 // If handler is nil, HandleOPTIONS clears the current set handler. In this case, the behaviour is to call the
 // registered handler normally, if there is one.
+//
+// If r has already started serving requests, HandleOPTIONS panics.
 func (r *Router) HandleOPTIONS(handler Handler) {
+	r.checkNotFrozen()
+
 	if handler == nil {
 		r.router.HandleOPTIONS = false
-		r.router.GlobalOPTIONS = nil
+		r.options = nil
+
+		return
 	}
 
-	r.router.GlobalOPTIONS = handler.Base()
+	r.router.HandleOPTIONS = true
+	r.options = handler
 }
 
 // HandleMethodNotAllowed registers handler to be called when there is a match to a route, but not with that method.
 // By default, Lit uses a wrapped http.Error with status code [405 Method Not Allowed].
 //
+// Unless disabled by [WithFallbackMiddlewares], global middlewares are applied to handler.
+//
 // If handler is nil, HandleMethodNotAllowed clears the current set handler. In this case, the behaviour is to call
 // the Not Found handler (either the one defined in HandleNotFound or the default one).
 //
+// If r has already started serving requests, HandleMethodNotAllowed panics.
+//
 // [405 Method Not Allowed]: https://developer.mozilla.org/en-US/docs/web/http/status/405
 func (r *Router) HandleMethodNotAllowed(handler Handler) {
+	r.checkNotFrozen()
+
 	if handler == nil {
 		r.router.HandleMethodNotAllowed = false
+		r.methodNotAllowed = nil
+
 		return
 	}
 
 	r.router.HandleMethodNotAllowed = true
-	r.router.MethodNotAllowed = handler.Base()
+	r.methodNotAllowed = handler
 }
 
 // GET registers handler for path and GET method and optional local middlewares.
@@ -152,8 +295,8 @@ func (r *Router) HandleMethodNotAllowed(handler Handler) {
 // It's equivalent to:
 //
 //	Handle(path, "GET", handler, middlewares)
-func (r *Router) GET(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodGet, handler, middlewares...)
+func (r *Router) GET(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodGet, handler, middlewares...)
 }
 
 // POST registers handler for path and POST method and optional local middlewares.
@@ -161,8 +304,8 @@ func (r *Router) GET(path string, handler Handler, middlewares ...Middleware) {
 // It's equivalent to:
 //
 //	Handle(path, "POST", handler, middlewares)
-func (r *Router) POST(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodPost, handler, middlewares...)
+func (r *Router) POST(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodPost, handler, middlewares...)
 }
 
 // PUT registers handler for path and PUT method and optional local middlewares.
@@ -170,8 +313,8 @@ func (r *Router) POST(path string, handler Handler, middlewares ...Middleware) {
 // It's equivalent to:
 //
 //	Handle(path, "PUT", handler, middlewares)
-func (r *Router) PUT(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodPut, handler, middlewares...)
+func (r *Router) PUT(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodPut, handler, middlewares...)
 }
 
 // PATCH registers handler for path and PATCH method and optional local middlewares.
@@ -179,8 +322,8 @@ func (r *Router) PUT(path string, handler Handler, middlewares ...Middleware) {
 // It's equivalent to:
 //
 //	Handle(path, "PATCH", handler, middlewares)
-func (r *Router) PATCH(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodPatch, handler, middlewares...)
+func (r *Router) PATCH(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodPatch, handler, middlewares...)
 }
 
 // DELETE registers handler for path and DELETE method and optional local middlewares.
@@ -188,8 +331,8 @@ func (r *Router) PATCH(path string, handler Handler, middlewares ...Middleware)
 // It's equivalent to:
 //
 //	Handle(path, "DELETE", handler, middlewares)
-func (r *Router) DELETE(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodDelete, handler, middlewares...)
+func (r *Router) DELETE(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodDelete, handler, middlewares...)
 }
 
 // OPTIONS registers handler for path and OPTIONS method and optional local middlewares.
@@ -197,8 +340,8 @@ func (r *Router) DELETE(path string, handler Handler, middlewares ...Middleware)
 // It's equivalent to:
 //
 //	Handle(path, "OPTIONS", handler, middlewares)
-func (r *Router) OPTIONS(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodOptions, handler, middlewares...)
+func (r *Router) OPTIONS(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodOptions, handler, middlewares...)
 }
 
 // HEAD registers handler for path and HEAD method and optional local middlewares.
@@ -206,14 +349,139 @@ func (r *Router) OPTIONS(path string, handler Handler, middlewares ...Middleware
 // It's equivalent to:
 //
 //	Handle(path, "HEAD", handler, middlewares)
-func (r *Router) HEAD(path string, handler Handler, middlewares ...Middleware) {
-	r.Handle(path, http.MethodHead, handler, middlewares...)
+func (r *Router) HEAD(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, http.MethodHead, handler, middlewares...)
+}
+
+// QUERY registers handler for path and QUERY method and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "QUERY", handler, middlewares)
+func (r *Router) QUERY(path string, handler Handler, middlewares ...Middleware) *Route {
+	return r.Handle(path, MethodQuery, handler, middlewares...)
 }
 
 // ServeHTTP dispatches the request to the handler whose pattern most closely matches the request URL
 // and whose method is the same as the request method.
+//
+// The first call to ServeHTTP freezes r, building the handler chain of every route. If building it panics (for
+// instance, because a middleware panics when applied), every call to ServeHTTP panics with the same value.
 func (r *Router) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	r.router.ServeHTTP(writer, request)
+	r.freezeOnce.Do(r.freeze)
+
+	if r.freezePanic != nil {
+		panic(r.freezePanic)
+	}
+
+	tree := r.router
+	if h := r.matchHost(request.Host); h != nil {
+		tree = h.router
+	}
+
+	if r.serveMounted(tree, writer, request) {
+		return
+	}
+
+	if r.paths.rewrites() && r.serveRewritten(tree, writer, request) {
+		return
+	}
+
+	tree.ServeHTTP(writer, request)
+}
+
+func (r *Router) freeze() {
+	r.frozen.Store(true)
+
+	// Since sync.Once considers a panicking call done, the panic is kept so that the half-configured router is never
+	// used.
+	defer func() {
+		if value := recover(); value != nil {
+			r.freezePanic = value
+		}
+	}()
+
+	for _, versioning := range r.versionings {
+		versioning.freeze()
+	}
+
+	for _, route := range r.routes {
+		r.build(route)
+	}
+
+	for _, route := range r.mounts {
+		r.build(route)
+	}
+
+	if r.automaticHEAD {
+		r.freezeHEAD()
+	}
+
+	r.router.RedirectTrailingSlash = r.paths.redirectTrailingSlash && !r.paths.strictSlash
+	r.router.RedirectFixedPath = r.paths.redirectFixedPath && !r.paths.caseInsensitive
+
+	if r.constraintMismatch == nil {
+		r.constraintMismatch = r.notFound
+	}
+
+	r.constraintMismatch = r.fallback(r.constraintMismatch)
+
+	r.configure(r.router)
+	for _, h := range r.hosts {
+		r.configure(h.router)
+	}
+}
+
+// build builds the handler chain and the description of route.
+func (r *Router) build(route *Route) {
+	route.chain = transform(route.handler, route.localMiddlewares())
+	if route.timeout > 0 {
+		route.chain = TimeoutWith(route.timeout, route.timeoutResponse)(route.chain)
+	}
+
+	route.chain = transform(route.chain, r.middlewares)
+	route.info = route.Info()
+}
+
+// tree returns the routing tree of host, or the main one if host is nil.
+func (r *Router) tree(host *host) *httprouter.Router {
+	if host != nil {
+		return host.router
+	}
+
+	return r.router
+}
+
+// configure sets the fallback handlers of tree, copying the settings of the main tree.
+func (r *Router) configure(tree *httprouter.Router) {
+	tree.HandleOPTIONS = r.router.HandleOPTIONS
+	tree.HandleMethodNotAllowed = r.router.HandleMethodNotAllowed
+	tree.RedirectTrailingSlash = r.router.RedirectTrailingSlash
+	tree.RedirectFixedPath = r.router.RedirectFixedPath
+
+	tree.NotFound = r.fallback(r.notFound).Base()
+
+	if r.methodNotAllowed != nil {
+		tree.MethodNotAllowed = r.fallback(r.methodNotAllowed).Base()
+	}
+
+	if r.options != nil {
+		tree.GlobalOPTIONS = r.fallback(r.options).Base()
+	}
+}
+
+func (r *Router) fallback(handler Handler) Handler {
+	if r.fallbackMiddlewares {
+		return transform(handler, r.middlewares)
+	}
+
+	return handler
+}
+
+func (r *Router) checkNotFrozen() {
+	if r.frozen.Load() {
+		panic("router should not be modified after it has started serving requests")
+	}
 }
 
 func transform(handler Handler, middlewares []Middleware) Handler {
@@ -223,3 +491,21 @@ func transform(handler Handler, middlewares []Middleware) Handler {
 
 	return handler
 }
+
+func notFound(r *Request) Response {
+	return ResponseFunc(func(w http.ResponseWriter) {
+		http.NotFound(w, r.Base())
+	})
+}
+
+func methodNotAllowed(_ *Request) Response {
+	return ResponseFunc(func(w http.ResponseWriter) {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	})
+}
+
+func allowOPTIONS(_ *Request) Response {
+	return ResponseFunc(func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
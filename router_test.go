@@ -1,8 +1,11 @@
 package lit_test
 
 import (
+	"fmt"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/jvcoutinho/lit"
@@ -56,6 +59,26 @@ func TestRouter_Handle(t *testing.T) {
 			},
 			panicValue: "method should not be empty",
 		},
+		{
+			description: "Handle_WhenMethodIsNotAValidToken_ShouldPanic",
+			path:        "/users",
+			method:      "GET USERS",
+			handler:     handler,
+			function: func(r *lit.Router, path string, method string, handler lit.Handler, middlewares ...lit.Middleware) {
+				r.Handle(path, method, handler, middlewares...)
+			},
+			panicValue: "method 'GET USERS' should be a valid token",
+		},
+		{
+			description: "Handle_WhenMethodIsExtended_ShouldRegisterHandler",
+			path:        "/users",
+			method:      lit.MethodPropfind,
+			handler:     handler,
+			function: func(r *lit.Router, path string, method string, handler lit.Handler, middlewares ...lit.Middleware) {
+				r.Handle(path, method, handler, middlewares...)
+			},
+			panicValue: `a handle is already registered for path '/users'`,
+		},
 		{
 			description: "Handle_WhenMiddlewaresContainsANilElement_ShouldPanic",
 			path:        "/users",
@@ -372,6 +395,35 @@ func TestRouter_Handle(t *testing.T) {
 			},
 			panicValue: `a handle is already registered for path '/users'`,
 		},
+		{
+			description: "QUERY_WhenPathDoesNotContainALeadingSlash_ShouldPanic",
+			path:        "users",
+			handler:     handler,
+			function: func(r *lit.Router, path string, _ string, handler lit.Handler, middlewares ...lit.Middleware) {
+				r.QUERY(path, handler, middlewares...)
+			},
+			panicValue: `path must begin with '/' in path 'users'`,
+		},
+		{
+			description: "QUERY_WhenHandlerIsNil_ShouldPanic",
+			path:        "/users",
+			handler:     nil,
+			function: func(r *lit.Router, path string, _ string, handler lit.Handler, middlewares ...lit.Middleware) {
+				r.QUERY(path, handler, middlewares...)
+			},
+			panicValue: "handler should not be nil",
+		},
+		{
+			description: "QUERY_ShouldRegisterHandler",
+			path:        "/users",
+			method:      lit.MethodQuery,
+			handler:     handler,
+			middlewares: []lit.Middleware{middleware},
+			function: func(r *lit.Router, path string, _ string, handler lit.Handler, middlewares ...lit.Middleware) {
+				r.QUERY(path, handler, middlewares...)
+			},
+			panicValue: `a handle is already registered for path '/users'`,
+		},
 	}
 
 	for _, test := range tests {
@@ -621,6 +673,20 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				"Allow": {"GET, OPTIONS"},
 			},
 		},
+		{
+			description: "GivenHandleOPTIONSIsClearedAndSetAgain_AndMethodIsOPTIONS_ShouldRespondHandlerResponse",
+			setupRouter: func(r *lit.Router) {
+				r.HandleOPTIONS(nil)
+				r.HandleOPTIONS(notContentHandler)
+				r.Handle("/users", http.MethodGet, printUsersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodOptions, "/users", nil),
+			expectedBody:       "",
+			expectedStatusCode: http.StatusNoContent,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS"},
+			},
+		},
 		{
 			description: "GivenHandleOPTIONSIsNotSet_AndMethodIsOPTIONS_ShouldRespondMethodNotAllowed",
 			setupRouter: func(r *lit.Router) {
@@ -636,6 +702,47 @@ func TestRouter_ServeHTTP(t *testing.T) {
 				"X-Content-Type-Options": {"nosniff"},
 			},
 		},
+		{
+			description: "GivenExtendedMethodsAreRegistered_AndMethodIsNotAllowed_ShouldRespondAllowHeaders",
+			setupRouter: func(r *lit.Router) {
+				r.Handle("/users", lit.MethodPropfind, printUsersHandler)
+				r.Handle("/users", lit.MethodPurge, printUsersHandler)
+				r.QUERY("/users", printUsersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users", nil),
+			expectedBody:       "Method Not Allowed\n",
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedHeader: http.Header{
+				"Allow":                  {"OPTIONS, PROPFIND, PURGE, QUERY"},
+				"Content-Type":           {"text/plain; charset=utf-8"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+		},
+		{
+			description: "GivenExtendedMethodsAreRegistered_AndMethodIsOPTIONS_ShouldRespondAllowHeaders",
+			setupRouter: func(r *lit.Router) {
+				r.GET("/users", printUsersHandler)
+				r.Handle("/users", lit.MethodReport, printUsersHandler)
+			},
+			request:            httptest.NewRequest(http.MethodOptions, "/users", nil),
+			expectedBody:       "",
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Allow": {"GET, OPTIONS, REPORT"},
+			},
+		},
+		{
+			description: "GivenExtendedMethodIsRegistered_ShouldReturnHandlerResponse",
+			setupRouter: func(r *lit.Router) {
+				r.QUERY("/users", printUsersHandler)
+			},
+			request:            httptest.NewRequest(lit.MethodQuery, "/users", nil),
+			expectedBody:       "users",
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type": {"text/plain; charset=utf-8"},
+			},
+		},
 		{
 			description: "GivenHandlerIsRegistered_ShouldReturnHandlerResponse",
 			setupRouter: func(r *lit.Router) {
@@ -685,6 +792,18 @@ func TestRouter_ServeHTTP(t *testing.T) {
 			expectedStatusCode: http.StatusAccepted,
 			expectedHeader:     http.Header{},
 		},
+		{
+			description: "GivenRouterHasGlobalMiddlewaresRegisteredAfterHandlers_ShouldUseThem",
+			setupRouter: func(r *lit.Router) {
+				r.Handle("/users", http.MethodGet, printUsersHandler)
+				r.Use(helloWorldMiddleware)
+				r.Use(byeWorldMiddleware)
+			},
+			request:            httptest.NewRequest(http.MethodGet, "/users", nil),
+			expectedBody:       "Hello, World!\nusers\nBye, World!",
+			expectedStatusCode: http.StatusAccepted,
+			expectedHeader:     http.Header{},
+		},
 		{
 			description: "GivenRouterHasGlobalMiddlewares_AndHandleHasLocalMiddlewares_ShouldUseThem",
 			setupRouter: func(r *lit.Router) {
@@ -723,3 +842,276 @@ func TestRouter_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestRouter_WhenServingRequests_ShouldBeFrozen(t *testing.T) {
+	t.Parallel()
+
+	var (
+		handler    = func(r *lit.Request) lit.Response { return nil }
+		middleware = func(h lit.Handler) lit.Handler { return h }
+	)
+
+	tests := []struct {
+		description string
+		modify      func(*lit.Router, *lit.Route)
+	}{
+		{
+			description: "Use",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.Use(middleware)
+			},
+		},
+		{
+			description: "Handle",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.Handle("/books", http.MethodGet, handler)
+			},
+		},
+		{
+			description: "GroupHandle",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.Group("/api").GET("/books", handler)
+			},
+		},
+		{
+			description: "RouteWithName",
+			modify: func(_ *lit.Router, route *lit.Route) {
+				route.WithName("users")
+			},
+		},
+		{
+			description: "Versioning",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.Versioning(lit.PathVersioning(), "1")
+			},
+		},
+		{
+			description: "RouteWithTimeoutResponse",
+			modify: func(_ *lit.Router, route *lit.Route) {
+				route.WithTimeoutResponse(nil)
+			},
+		},
+		{
+			description: "HandleNotFound",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.HandleNotFound(handler)
+			},
+		},
+		{
+			description: "HandleMethodNotAllowed",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.HandleMethodNotAllowed(handler)
+			},
+		},
+		{
+			description: "HandleOPTIONS",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.HandleOPTIONS(handler)
+			},
+		},
+		{
+			description: "HandleError",
+			modify: func(r *lit.Router, _ *lit.Route) {
+				r.HandleError(nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			route := router.GET("/users", handler)
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+
+			// Act
+			// Assert
+			require.PanicsWithValue(t, "router should not be modified after it has started serving requests", func() {
+				test.modify(router, route)
+			})
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_WhenFreezingPanics_ShouldPanicInEveryRequest(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	router := lit.NewRouter()
+	router.GET("/users", func(r *lit.Request) lit.Response {
+		return nil
+	}, func(h lit.Handler) lit.Handler {
+		panic("invalid middleware")
+	})
+
+	// Act
+	// Assert
+	for i := 0; i < 2; i++ {
+		require.PanicsWithValue(t, "invalid middleware", func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_Fallbacks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		printUsersHandler = func(r *lit.Request) lit.Response {
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Write([]byte("users"))
+			})
+		}
+
+		headerMiddleware = func(h lit.Handler) lit.Handler {
+			return func(r *lit.Request) lit.Response {
+				res := h(r)
+
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					w.Header().Set("X-Middleware", "true")
+					res.Write(w)
+				})
+			}
+		}
+	)
+
+	tests := []struct {
+		description        string
+		options            []lit.RouterOption
+		request            *http.Request
+		expectedStatusCode int
+		expectedHeader     string
+	}{
+		{
+			description:        "GivenHandlerIsNotRegistered_ShouldApplyGlobalMiddlewares",
+			request:            httptest.NewRequest(http.MethodGet, "/books", nil),
+			expectedStatusCode: http.StatusNotFound,
+			expectedHeader:     "true",
+		},
+		{
+			description:        "GivenHandlerIsRegisteredForAnotherMethod_ShouldApplyGlobalMiddlewares",
+			request:            httptest.NewRequest(http.MethodPost, "/users", nil),
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedHeader:     "true",
+		},
+		{
+			description:        "GivenMethodIsOPTIONS_ShouldApplyGlobalMiddlewares",
+			request:            httptest.NewRequest(http.MethodOptions, "/users", nil),
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     "true",
+		},
+		{
+			description:        "GivenFallbackMiddlewaresAreDisabled_AndHandlerIsNotRegistered_ShouldNotApplyGlobalMiddlewares",
+			options:            []lit.RouterOption{lit.WithFallbackMiddlewares(false)},
+			request:            httptest.NewRequest(http.MethodGet, "/books", nil),
+			expectedStatusCode: http.StatusNotFound,
+			expectedHeader:     "",
+		},
+		{
+			description:        "GivenFallbackMiddlewaresAreDisabled_AndMethodIsOPTIONS_ShouldNotApplyGlobalMiddlewares",
+			options:            []lit.RouterOption{lit.WithFallbackMiddlewares(false)},
+			request:            httptest.NewRequest(http.MethodOptions, "/users", nil),
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter(test.options...)
+			router.Use(headerMiddleware)
+			router.GET("/users", printUsersHandler)
+
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, test.request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedHeader, recorder.Header().Get("X-Middleware"))
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_WhenServingConcurrentRequests_ShouldNotLeakURIParameters(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		router   = lit.NewRouter()
+		retained = make(chan *lit.Request, 1000)
+		wg       sync.WaitGroup
+	)
+
+	respondParameters := func(r *lit.Request) lit.Response {
+		retained <- r.Clone()
+
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			fmt.Fprint(w, r.URIParameters())
+		})
+	}
+
+	router.GET("/users/:user_id", respondParameters)
+	router.GET("/users/:user_id/books/:book_id", respondParameters)
+	router.GET("/static/*filepath", respondParameters)
+	router.GET("/health", respondParameters)
+
+	tests := []struct {
+		path               string
+		expectedParameters map[string]string
+	}{
+		{"/users/1", map[string]string{"user_id": "1"}},
+		{"/users/2/books/3", map[string]string{"user_id": "2", "book_id": "3"}},
+		{"/static/css/main.css", map[string]string{"filepath": "/css/main.css"}},
+		{"/health", map[string]string{}},
+	}
+
+	type result struct {
+		expectedBody string
+		body         string
+	}
+
+	results := make(chan result, cap(retained))
+
+	// Act
+	for i := 0; i < cap(retained); i++ {
+		test := tests[i%len(tests)]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			results <- result{fmt.Sprint(test.expectedParameters), recorder.Body.String()}
+		}()
+	}
+
+	wg.Wait()
+	close(retained)
+	close(results)
+
+	// Assert
+	for result := range results {
+		require.Equal(t, result.expectedBody, result.body)
+	}
+
+	for request := range retained {
+		matched := false
+		for _, test := range tests {
+			matched = matched || maps.Equal(test.expectedParameters, request.URIParameters())
+		}
+
+		require.True(t, matched, "unexpected URI parameters %v", request.URIParameters())
+	}
+}
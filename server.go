@@ -0,0 +1,192 @@
+package lit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+const defaultShutdownTimeout = 10 * time.Second
+
+// Server listens and serves HTTP requests, shutting down gracefully when its context is done.
+type Server struct {
+	server          *http.Server
+	network         string
+	certFile        string
+	keyFile         string
+	shutdownTimeout time.Duration
+	onStart         []func(address net.Addr)
+	onShutdown      []func()
+}
+
+// ServerOption configures a [Server].
+type ServerOption func(s *Server)
+
+// WithReadTimeout sets the maximum duration for reading an entire request, including the body.
+// By default, there is no timeout.
+func WithReadTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.server.ReadTimeout = timeout
+	}
+}
+
+// WithReadHeaderTimeout sets the maximum duration for reading the header of a request.
+// By default, the read timeout is used.
+func WithReadHeaderTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.server.ReadHeaderTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets the maximum duration before timing out writes of a response.
+// By default, there is no timeout.
+func WithWriteTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.server.WriteTimeout = timeout
+	}
+}
+
+// WithIdleTimeout sets the maximum duration to wait for the next request when keep-alives are enabled.
+// By default, the read timeout is used.
+func WithIdleTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.server.IdleTimeout = timeout
+	}
+}
+
+// WithShutdownTimeout sets the maximum duration to wait for in-flight requests to finish once the server is shutting
+// down. By default, it is 10 seconds.
+func WithShutdownTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// WithTLS makes the server accept HTTPS connections, using the certificate and matching private key
+// from the given files.
+func WithTLS(certFile, keyFile string) ServerOption {
+	return func(s *Server) {
+		s.certFile = certFile
+		s.keyFile = keyFile
+	}
+}
+
+// WithUnixSocket makes the server listen on a Unix domain socket. In this case, the address passed to
+// [*Server.ListenAndServe] is the path of the socket file.
+func WithUnixSocket() ServerOption {
+	return func(s *Server) {
+		s.network = "unix"
+	}
+}
+
+// OnStart registers hook to be called once the server is listening, receiving the actual address it is listening on.
+// It is useful to discover the port when listening on an ephemeral one (such as "127.0.0.1:0").
+func OnStart(hook func(address net.Addr)) ServerOption {
+	return func(s *Server) {
+		s.onStart = append(s.onStart, hook)
+	}
+}
+
+// OnShutdown registers hook to be called once the server has shut down, after in-flight requests are drained (or
+// their connections are closed, if the shutdown timeout expires). It is also called if the server fails after it has
+// started. It is useful to release resources used by the handlers, such as database connections.
+func OnShutdown(hook func()) ServerOption {
+	return func(s *Server) {
+		s.onShutdown = append(s.onShutdown, hook)
+	}
+}
+
+// NewServer creates a new [Server] instance that serves requests with handler, configured by options.
+//
+// If handler is nil, NewServer panics.
+func NewServer(handler http.Handler, options ...ServerOption) *Server {
+	if handler == nil {
+		panic("handler should not be nil")
+	}
+
+	s := &Server{
+		server:          &http.Server{Handler: handler},
+		network:         "tcp",
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// ListenAndServe listens on address and serves requests until ctx is done. Then, it stops accepting new connections
+// and waits for in-flight requests to finish (up to the shutdown timeout, after which their connections are closed)
+// before returning.
+//
+// A common use is to pass a context that is done when the process receives an interrupt signal:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//
+//	if err := lit.NewServer(r).ListenAndServe(ctx, ":8080"); err != nil {
+//		log.Fatalln(err)
+//	}
+//
+// ListenAndServe returns an error if it can't listen on address, if the server fails or if the in-flight requests
+// could not be drained in time. Otherwise, it returns nil.
+func (s *Server) ListenAndServe(ctx context.Context, address string) error {
+	listener, err := net.Listen(s.network, address)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range s.onStart {
+		hook(listener.Addr())
+	}
+
+	defer func() {
+		for _, hook := range s.onShutdown {
+			hook()
+		}
+	}()
+
+	serveErr := make(chan error, 1)
+
+	go func() {
+		if s.certFile != "" || s.keyFile != "" {
+			serveErr <- s.server.ServeTLS(listener, s.certFile, s.keyFile)
+		} else {
+			serveErr <- s.server.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		_ = listener.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	// The connections of the requests that could not be drained in time are closed.
+	if err = s.server.Shutdown(shutdownCtx); err != nil {
+		_ = s.server.Close()
+	}
+
+	if serveErr := <-serveErr; !errors.Is(serveErr, http.ErrServerClosed) {
+		err = errors.Join(err, serveErr)
+	}
+
+	return err
+}
+
+// ListenAndServe listens on address and serves requests with r until ctx is done, shutting down gracefully.
+//
+// It's equivalent to:
+//
+//	NewServer(r, options...).ListenAndServe(ctx, address)
+func (r *Router) ListenAndServe(ctx context.Context, address string, options ...ServerOption) error {
+	return NewServer(r, options...).ListenAndServe(ctx, address)
+}
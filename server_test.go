@@ -0,0 +1,330 @@
+package lit_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "handler should not be nil", func() {
+		lit.NewServer(nil)
+	})
+}
+
+func TestServer_ListenAndServe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		network     string
+		address     func(t *testing.T) string
+		options     func(t *testing.T) []lit.ServerOption
+		client      func(address net.Addr) *http.Client
+		scheme      string
+	}{
+		{
+			description: "WhenListeningOnTCP_ShouldServeRequests",
+			address: func(_ *testing.T) string {
+				return "127.0.0.1:0"
+			},
+			client: func(_ net.Addr) *http.Client {
+				return &http.Client{}
+			},
+			scheme: "http",
+		},
+		{
+			description: "WhenListeningOnUnixSocket_ShouldServeRequests",
+			address: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "lit.sock")
+			},
+			options: func(_ *testing.T) []lit.ServerOption {
+				return []lit.ServerOption{lit.WithUnixSocket()}
+			},
+			client: func(address net.Addr) *http.Client {
+				return &http.Client{Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return (&net.Dialer{}).DialContext(ctx, "unix", address.String())
+					},
+				}}
+			},
+			scheme: "http",
+		},
+		{
+			description: "WhenTLSIsEnabled_ShouldServeHTTPSRequests",
+			address: func(_ *testing.T) string {
+				return "127.0.0.1:0"
+			},
+			options: func(t *testing.T) []lit.ServerOption {
+				certFile, keyFile := writeCertificate(t)
+				return []lit.ServerOption{lit.WithTLS(certFile, keyFile)}
+			},
+			client: func(_ net.Addr) *http.Client {
+				return &http.Client{Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+				}}
+			},
+			scheme: "https",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			router := lit.NewRouter()
+			router.GET("/", func(r *lit.Request) lit.Response {
+				return lit.ResponseFunc(func(w http.ResponseWriter) {
+					w.Write([]byte("Hello, World!"))
+				})
+			})
+
+			var (
+				ctx, cancel = context.WithCancel(context.Background())
+				started     = make(chan net.Addr, 1)
+				shutdown    = make(chan struct{})
+				result      = make(chan error, 1)
+			)
+			defer cancel()
+
+			options := []lit.ServerOption{
+				lit.OnStart(func(address net.Addr) { started <- address }),
+				lit.OnShutdown(func() { close(shutdown) }),
+			}
+
+			if test.options != nil {
+				options = append(options, test.options(t)...)
+			}
+
+			// Act
+			go func() {
+				result <- router.ListenAndServe(ctx, test.address(t), options...)
+			}()
+
+			address := <-started
+
+			res, err := test.client(address).Get(test.scheme + "://" + hostOf(address) + "/")
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			cancel()
+
+			// Assert
+			require.Equal(t, "Hello, World!", string(body))
+			require.NoError(t, <-result)
+
+			_, open := <-shutdown
+			require.False(t, open)
+		})
+	}
+}
+
+func TestServer_ListenAndServe_WhenContextIsDone_ShouldDrainInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		started     = make(chan net.Addr, 1)
+		inFlight    = make(chan struct{})
+		result      = make(chan error, 1)
+	)
+	defer cancel()
+
+	router := lit.NewRouter()
+	router.GET("/slow", func(r *lit.Request) lit.Response {
+		close(inFlight)
+		time.Sleep(100 * time.Millisecond)
+
+		return lit.ResponseFunc(func(w http.ResponseWriter) {
+			w.Write([]byte("done"))
+		})
+	})
+
+	server := lit.NewServer(router, lit.OnStart(func(address net.Addr) { started <- address }))
+
+	go func() {
+		result <- server.ListenAndServe(ctx, "127.0.0.1:0")
+	}()
+
+	address := <-started
+
+	// Act
+	go func() {
+		<-inFlight
+		cancel()
+	}()
+
+	res, err := http.Get("http://" + address.String() + "/slow")
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	// Assert
+	require.Equal(t, "done", string(body))
+	require.NoError(t, <-result)
+}
+
+func TestServer_ListenAndServe_WhenShutdownTimeoutExpires_ShouldReturnError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		started     = make(chan net.Addr, 1)
+		inFlight    = make(chan struct{})
+		release     = make(chan struct{})
+		shutdown    = make(chan struct{})
+		result      = make(chan error, 1)
+		clientErr   = make(chan error, 1)
+	)
+	defer cancel()
+
+	router := lit.NewRouter()
+	router.GET("/slow", func(r *lit.Request) lit.Response {
+		close(inFlight)
+		<-release
+
+		return nil
+	})
+
+	server := lit.NewServer(router,
+		lit.WithShutdownTimeout(10*time.Millisecond),
+		lit.OnStart(func(address net.Addr) { started <- address }),
+		lit.OnShutdown(func() { close(shutdown) }),
+	)
+
+	go func() {
+		result <- server.ListenAndServe(ctx, "127.0.0.1:0")
+	}()
+
+	address := <-started
+
+	go func() {
+		res, err := http.Get("http://" + address.String() + "/slow")
+		if err == nil {
+			res.Body.Close()
+		}
+
+		clientErr <- err
+	}()
+
+	<-inFlight
+
+	// Act
+	cancel()
+	err := <-result
+	closedErr := <-clientErr
+	close(release)
+
+	// Assert
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Error(t, closedErr)
+
+	_, open := <-shutdown
+	require.False(t, open)
+}
+
+func TestServer_ListenAndServe_WhenServerFails_ShouldCallShutdownHooks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		missingFile = filepath.Join(t.TempDir(), "missing.pem")
+		shutdown    = make(chan struct{})
+	)
+
+	server := lit.NewServer(lit.NewRouter(),
+		lit.WithTLS(missingFile, missingFile),
+		lit.OnShutdown(func() { close(shutdown) }),
+	)
+
+	// Act
+	err := server.ListenAndServe(context.Background(), "127.0.0.1:0")
+
+	// Assert
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, open := <-shutdown
+	require.False(t, open)
+}
+
+func TestServer_ListenAndServe_WhenAddressIsInvalid_ShouldReturnError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	server := lit.NewServer(lit.NewRouter())
+
+	// Act
+	err := server.ListenAndServe(context.Background(), "invalid address")
+
+	// Assert
+	require.Error(t, err)
+}
+
+func hostOf(address net.Addr) string {
+	if address.Network() == "unix" {
+		return "unix"
+	}
+
+	return address.String()
+}
+
+func writeCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"Lit"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	privateKey, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	var (
+		dir      = t.TempDir()
+		certFile = filepath.Join(dir, "cert.pem")
+		keyFile  = filepath.Join(dir, "key.pem")
+	)
+
+	require.NoError(t, os.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKey}), 0o600))
+
+	return certFile, keyFile
+}
@@ -0,0 +1,114 @@
+package lit
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// Timeout is a middleware that bounds how long a handler may run. The context of the request passed to the handler
+// has a deadline of d from now and, if the handler does not return in time, a 503 Service Unavailable response is
+// written instead. A late response of the handler is discarded.
+//
+// Since the handler keeps running after the deadline, it receives a copy of the request (see [*Request.Clone]) and
+// should stop working once its context is done. If the handler panics before the deadline, Timeout panics with the
+// same value. Panics after the deadline are logged.
+//
+// If the context of the request is done before the deadline (for instance, because the client has disconnected),
+// the response of the handler is waited for, since the request has not timed out.
+//
+// It's equivalent to:
+//
+//	TimeoutWith(d, nil)
+//
+// If d is not positive, Timeout panics.
+func Timeout(d time.Duration) Middleware {
+	return TimeoutWith(d, nil)
+}
+
+// TimeoutWith is like [Timeout], but writes response if the handler does not return in time. If response is nil,
+// a 503 Service Unavailable response is written.
+//
+// If d is not positive, TimeoutWith panics.
+func TimeoutWith(d time.Duration, response Response) Middleware {
+	if d <= 0 {
+		panic("d should be positive")
+	}
+
+	if response == nil {
+		response = serviceUnavailable
+	}
+
+	return func(h Handler) Handler {
+		return func(r *Request) Response {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+
+			var (
+				request  = r.Clone().WithContext(ctx)
+				result   = make(chan Response, 1)
+				panicked = make(chan panicValue, 1)
+			)
+
+			go func() {
+				defer func() {
+					if value := recover(); value != nil {
+						panicked <- panicValue{value, debug.Stack()}
+					}
+				}()
+
+				result <- h(request)
+			}()
+
+			deadline := ctx.Done()
+
+			for {
+				select {
+				case res := <-result:
+					if res == nil {
+						cancel()
+						return nil
+					}
+
+					return ResponseFunc(func(w http.ResponseWriter) {
+						defer cancel()
+						res.Write(w)
+					})
+				case value := <-panicked:
+					cancel()
+					panic(value.value)
+				case <-deadline:
+					if r.Context().Err() != nil {
+						deadline = nil
+						continue
+					}
+
+					cancel()
+
+					go reportLatePanic(request, result, panicked)
+
+					return response
+				}
+			}
+		}
+	}
+}
+
+type panicValue struct {
+	value any
+	stack []byte
+}
+
+// reportLatePanic logs the panic of the handler of r after the deadline, if it panics.
+func reportLatePanic(r *Request, result <-chan Response, panicked <-chan panicValue) {
+	select {
+	case <-result:
+	case value := <-panicked:
+		log.Printf("%s %s: panic after timeout: %v\n%s", r.Method(), r.URL(), value.value, value.stack)
+	}
+}
+
+var serviceUnavailable = ResponseFunc(func(w http.ResponseWriter) {
+	http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+})
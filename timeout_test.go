@@ -0,0 +1,188 @@
+package lit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description        string
+		middleware         lit.Middleware
+		handler            lit.Handler
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description: "WhenHandlerReturnsInTime_ShouldRespondHandlerResponse",
+			middleware:  lit.Timeout(time.Second),
+			handler: func(r *lit.Request) lit.Response {
+				return render.OK("done")
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"message":"done"}`,
+		},
+		{
+			description: "WhenHandlerDoesNotReturnInTime_ShouldRespondServiceUnavailable",
+			middleware:  lit.Timeout(10 * time.Millisecond),
+			handler: func(r *lit.Request) lit.Response {
+				<-r.Context().Done()
+				return render.OK("late")
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "Service Unavailable\n",
+		},
+		{
+			description: "WhenHandlerDoesNotReturnInTime_AndResponseIsSet_ShouldRespondIt",
+			middleware:  lit.TimeoutWith(10*time.Millisecond, render.JSON(http.StatusGatewayTimeout, "too slow")),
+			handler: func(r *lit.Request) lit.Response {
+				time.Sleep(50 * time.Millisecond)
+				return render.OK("late")
+			},
+			expectedStatusCode: http.StatusGatewayTimeout,
+			expectedBody:       `{"message":"too slow"}`,
+		},
+		{
+			description: "WhenHandlerChecksContext_ShouldHaveDeadline",
+			middleware:  lit.Timeout(time.Second),
+			handler: func(r *lit.Request) lit.Response {
+				deadline, ok := r.Context().Deadline()
+				if !ok || time.Until(deadline) > time.Second {
+					return render.InternalServerError("no deadline")
+				}
+
+				return render.NoContent()
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedBody:       "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				r        = lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+				recorder = httptest.NewRecorder()
+			)
+
+			// Act
+			response := test.middleware(test.handler)(r)
+			response.Write(recorder)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestTimeout_WhenDurationIsNotPositive_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithValue(t, "d should be positive", func() {
+		lit.Timeout(0)
+	})
+}
+
+func TestTimeout_WhenHandlerPanics_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		r       = lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil))
+		handler = lit.Timeout(time.Second)(func(r *lit.Request) lit.Response {
+			panic("scary!")
+		})
+	)
+
+	// Act
+	// Assert
+	require.PanicsWithValue(t, "scary!", func() {
+		handler(r)
+	})
+}
+
+func TestTimeout_WhenRequestIsCanceled_ShouldRespondHandlerResponse(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		r           = lit.NewRequest(httptest.NewRequest(http.MethodGet, "/users", nil).WithContext(ctx))
+		recorder    = httptest.NewRecorder()
+		handler     = lit.Timeout(time.Second)(func(r *lit.Request) lit.Response {
+			<-r.Context().Done()
+			return render.OK("canceled")
+		})
+	)
+
+	cancel()
+
+	// Act
+	handler(r).Write(recorder)
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, `{"message":"canceled"}`, recorder.Body.String())
+}
+
+func TestRoute_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		router   = lit.NewRouter()
+		finished = make(chan error, 1)
+		recorder = httptest.NewRecorder()
+	)
+
+	router.GET("/users/:id", func(r *lit.Request) lit.Response {
+		<-r.Context().Done()
+		finished <- context.Cause(r.Context())
+
+		return render.OK(r.URIParameters()["id"])
+	}).WithTimeout(10 * time.Millisecond)
+
+	// Act
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	// Assert
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Equal(t, "Service Unavailable\n", recorder.Body.String())
+	require.ErrorIs(t, <-finished, context.DeadlineExceeded)
+}
+
+func TestRoute_WithTimeoutResponse(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var (
+		router   = lit.NewRouter()
+		recorder = httptest.NewRecorder()
+	)
+
+	router.GET("/users", func(r *lit.Request) lit.Response {
+		<-r.Context().Done()
+		return render.OK("late")
+	}).WithTimeout(10 * time.Millisecond).WithTimeoutResponse(render.JSON(http.StatusGatewayTimeout, "too slow"))
+
+	// Act
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	// Assert
+	require.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	require.Equal(t, `{"message":"too slow"}`, recorder.Body.String())
+}
@@ -0,0 +1,117 @@
+// Package typed contains an adapter that turns business functions into [lit.Handler], binding, validating and
+// rendering their inputs and outputs automatically.
+//
+// A typed function receives the context of the request and a struct with the request data, bound by
+// [bind.Request], and returns a result or an error. Since it doesn't depend on [*lit.Request], it can be unit tested
+// by simply calling it:
+//
+//	type GetUserRequest struct {
+//		ID int `uri:"id"`
+//	}
+//
+//	func GetUser(ctx context.Context, req GetUserRequest) (User, error) {
+//		return users.Find(ctx, req.ID)
+//	}
+//
+//	r.GET("/users/:id", typed.Handler(GetUser))
+package typed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/bind"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/jvcoutinho/lit/validate"
+)
+
+// ErrorMapper maps an error returned by a typed function to a response. If it returns nil, the default mapping is
+// used (see [lit.ErrorResponse]).
+type ErrorMapper func(err error) lit.Response
+
+// Option configures a typed handler.
+type Option func(c *config)
+
+type config struct {
+	mapError ErrorMapper
+}
+
+// WithErrorMapper sets mapper as the function that maps the errors returned by the typed function to responses.
+//
+// If mapper is nil, WithErrorMapper panics.
+func WithErrorMapper(mapper ErrorMapper) Option {
+	if mapper == nil {
+		panic("mapper should not be nil")
+	}
+
+	return func(c *config) {
+		c.mapError = mapper
+	}
+}
+
+// Handler converts function into a [lit.Handler], configured by options.
+//
+// The handler binds the request into T with [bind.Request] and calls function with the context of the request.
+// If function succeeds, its result is rendered with [render.OK]. Otherwise, the response depends on the error:
+//
+//   - If the request could not be bound, such as in [bind.Error], it responds 400 Bad Request;
+//   - If the request body has an unsupported Content-Type, it responds 415 Unsupported Media Type;
+//   - If the bound request is not valid ([validate.Error]), it responds 422 Unprocessable Content;
+//   - If function returns an error, it is mapped by the [ErrorMapper] set by [WithErrorMapper]. By default, it's
+//     responded by [lit.ErrorResponse], as by the default error handler of [lit.Router]: errors that implement
+//     [lit.StatusCoder] determine the status code, and the other ones are logged and responded with 500 Internal
+//     Server Error, without exposing the error.
+//
+// If function is nil or T is not a struct type, Handler panics.
+func Handler[T any, U any](function func(ctx context.Context, req T) (U, error), options ...Option) lit.Handler {
+	if function == nil {
+		panic("function should not be nil")
+	}
+
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() != reflect.Struct {
+		panic("T must be a struct type")
+	}
+
+	c := &config{}
+	for _, option := range options {
+		option(c)
+	}
+
+	return func(r *lit.Request) lit.Response {
+		req, err := bind.Request[T](r)
+		if err != nil {
+			return bindingError(err)
+		}
+
+		result, err := function(r.Context(), req)
+		if err != nil {
+			if c.mapError == nil {
+				return lit.ErrorResponse(r, err)
+			}
+
+			if response := c.mapError(err); response != nil {
+				return response
+			}
+
+			return lit.ErrorResponse(r, err)
+		}
+
+		return render.OK(result)
+	}
+}
+
+func bindingError(err error) lit.Response {
+	var validationError validate.Error
+
+	switch {
+	case errors.As(err, &validationError):
+		return render.UnprocessableContent(err)
+	case errors.Is(err, bind.ErrUnsupportedContentType):
+		return render.JSON(http.StatusUnsupportedMediaType, err)
+	default:
+		return render.BadRequest(err)
+	}
+}
@@ -0,0 +1,197 @@
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/jvcoutinho/lit/render"
+	"github.com/jvcoutinho/lit/typed"
+	"github.com/jvcoutinho/lit/validate"
+	"github.com/stretchr/testify/require"
+)
+
+var errUserNotFound = errors.New("user not found")
+
+type getUserRequest struct {
+	ID    int    `uri:"id"`
+	Token string `header:"Authorization"`
+}
+
+func (r *getUserRequest) Validate() []validate.Field {
+	return []validate.Field{
+		validate.Greater(&r.ID, 0),
+	}
+}
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func getUser(_ context.Context, req getUserRequest) (user, error) {
+	switch req.ID {
+	case 1:
+		return user{1, "John"}, nil
+	case 2:
+		return user{}, errUserNotFound
+	case 4:
+		return user{}, validate.Error{Violations: []validate.Field{{Message: "user is inactive"}}}
+	default:
+		return user{}, errors.New("database is down")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	mapper := func(err error) lit.Response {
+		if errors.Is(err, errUserNotFound) {
+			return render.NotFound(err)
+		}
+
+		return nil
+	}
+
+	tests := []struct {
+		description        string
+		options            []typed.Option
+		id                 string
+		contentType        string
+		body               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			description:        "WhenFunctionSucceeds_ShouldRenderResult",
+			id:                 "1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"id":1,"name":"John"}`,
+		},
+		{
+			description:        "WhenRequestCanNotBeBound_ShouldRespondBadRequest",
+			id:                 "one",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"message":"id: one is not a valid int: invalid syntax"}`,
+		},
+		{
+			description:        "WhenContentTypeIsUnsupported_ShouldRespondUnsupportedMediaType",
+			id:                 "1",
+			contentType:        "application/pdf",
+			body:               "%PDF",
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedBody:       `{"message":"unsupported Content-Type"}`,
+		},
+		{
+			description:        "WhenRequestIsInvalid_ShouldRespondUnprocessableContent",
+			id:                 "0",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"message":"id should be greater than 0"}`,
+		},
+		{
+			description:        "WhenFunctionFails_ShouldRespondInternalServerError",
+			id:                 "2",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"message":"Internal Server Error"}`,
+		},
+		{
+			description:        "WhenFunctionFailsWithStatusCoder_ShouldRespondItsStatusCode",
+			id:                 "4",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"message":"user is inactive"}`,
+		},
+		{
+			description:        "WhenFunctionFailsWithStatusCoder_AndErrorMapperDoesNotMapError_ShouldRespondItsStatusCode",
+			options:            []typed.Option{typed.WithErrorMapper(mapper)},
+			id:                 "4",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedBody:       `{"message":"user is inactive"}`,
+		},
+		{
+			description:        "WhenFunctionFails_AndErrorMapperMapsError_ShouldRespondMappedResponse",
+			options:            []typed.Option{typed.WithErrorMapper(mapper)},
+			id:                 "2",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"message":"user not found"}`,
+		},
+		{
+			description:        "WhenFunctionFails_AndErrorMapperDoesNotMapError_ShouldRespondInternalServerError",
+			options:            []typed.Option{typed.WithErrorMapper(mapper)},
+			id:                 "3",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"message":"Internal Server Error"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				router   = lit.NewRouter()
+				request  = httptest.NewRequest(http.MethodGet, "/users/"+test.id, strings.NewReader(test.body))
+				recorder = httptest.NewRecorder()
+			)
+
+			if test.contentType != "" {
+				request.Header.Set("Content-Type", test.contentType)
+			}
+
+			router.GET("/users/:id", typed.Handler(getUser, test.options...))
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_ShouldPanic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		function    func()
+		panicValue  string
+	}{
+		{
+			description: "WhenFunctionIsNil_ShouldPanic",
+			function: func() {
+				typed.Handler[getUserRequest, user](nil)
+			},
+			panicValue: "function should not be nil",
+		},
+		{
+			description: "WhenTypeParameterIsNotStruct_ShouldPanic",
+			function: func() {
+				typed.Handler(func(ctx context.Context, req int) (int, error) { return req, nil })
+			},
+			panicValue: "T must be a struct type",
+		},
+		{
+			description: "WhenErrorMapperIsNil_ShouldPanic",
+			function: func() {
+				typed.WithErrorMapper(nil)
+			},
+			panicValue: "mapper should not be nil",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.PanicsWithValue(t, test.panicValue, test.function)
+		})
+	}
+}
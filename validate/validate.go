@@ -20,7 +20,10 @@
 // [package-level examples]: https://pkg.go.dev/github.com/jvcoutinho/lit/validate#pkg-examples
 package validate
 
-import "strings"
+import (
+	"net/http"
+	"strings"
+)
 
 // Validatable structs can be validated.
 type Validatable interface {
@@ -48,6 +51,11 @@ func (e Error) Error() string {
 	return msg.String()
 }
 
+// StatusCode returns 422 Unprocessable Content, so that handlers that return this error respond it appropriately.
+func (e Error) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
 // Field represents a field validation.
 type Field struct {
 	// Determines if this validation has succeeded.
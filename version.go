@@ -0,0 +1,373 @@
+package lit
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionStrategy defines how the API version requested by a client is determined.
+//
+// Use [MediaTypeVersioning], [HeaderVersioning] or [PathVersioning] to create one.
+type VersionStrategy struct {
+	version       func(r *Request) string
+	vary          string
+	prefix        string
+	unknownStatus int
+}
+
+// MediaTypeVersioning determines the API version from the vendor media type of the Accept header, in the form
+// "application/vnd.<vendor>.v<version>+<suffix>". For example, with vendor "acme", a request with the header
+//
+//	Accept: application/vnd.acme.v2+json
+//
+// requests the version "2". Requests for unknown versions are responded with 406 Not Acceptable.
+//
+// If vendor is empty, MediaTypeVersioning panics.
+func MediaTypeVersioning(vendor string) VersionStrategy {
+	if vendor == "" {
+		panic("vendor should not be empty")
+	}
+
+	prefix := "application/vnd." + strings.ToLower(vendor) + ".v"
+
+	return VersionStrategy{
+		version: func(r *Request) string {
+			for _, accept := range r.Header().Values("Accept") {
+				for _, mediaRange := range strings.Split(accept, ",") {
+					mediaType, _, err := mime.ParseMediaType(mediaRange)
+					if err != nil || !strings.HasPrefix(mediaType, prefix) {
+						continue
+					}
+
+					version, _, _ := strings.Cut(mediaType[len(prefix):], "+")
+
+					return version
+				}
+			}
+
+			return ""
+		},
+		vary:          "Accept",
+		unknownStatus: http.StatusNotAcceptable,
+	}
+}
+
+// HeaderVersioning determines the API version from the value of the header field, such as "API-Version". Requests
+// for unknown versions are responded with 400 Bad Request.
+//
+// If field is empty, HeaderVersioning panics.
+func HeaderVersioning(field string) VersionStrategy {
+	if field == "" {
+		panic("field should not be empty")
+	}
+
+	return VersionStrategy{
+		version: func(r *Request) string {
+			return strings.TrimSpace(r.Header().Get(field))
+		},
+		vary:          http.CanonicalHeaderKey(field),
+		unknownStatus: http.StatusBadRequest,
+	}
+}
+
+// PathVersioning determines the API version from a path prefix in the form "/v<version>", such as in "/v2/users".
+// Routes of the default version are also registered without the prefix.
+//
+// Since each version has its own routes, requests for unknown versions are handled as any other request that does
+// not match a route.
+func PathVersioning() VersionStrategy {
+	return VersionStrategy{prefix: "/v"}
+}
+
+// Versioning registers handlers for the same methods and paths in different versions of an API, dispatching each
+// request to the handler of the version it requests.
+//
+// Create a new Versioning using the [*Router.Versioning] method.
+type Versioning struct {
+	router         *Router
+	strategy       VersionStrategy
+	defaultVersion string
+	versions       map[string]*APIVersion
+	routes         map[string]*versionedRoute
+}
+
+// APIVersion registers handlers for a single version of an API.
+//
+// Create new API versions using the [*Versioning.Version] method.
+type APIVersion struct {
+	versioning  *Versioning
+	name        string
+	middlewares []Middleware
+	deprecation time.Time
+	sunset      time.Time
+}
+
+// versionedRoute is a route shared by several versions, that dispatches each request to the route of the version it
+// requests. The routes of the versions are not registered in the router, and their middlewares are applied when it
+// is frozen.
+type versionedRoute struct {
+	versioning *Versioning
+	route      *Route
+	routes     map[string]*Route
+	first      string
+}
+
+// Versioning creates a new [Versioning] of routes that determines the version requested by strategy. Requests that
+// don't specify a version are handled by the routes of defaultVersion.
+//
+// If defaultVersion is empty or r has already started serving requests, Versioning panics.
+func (r *Router) Versioning(strategy VersionStrategy, defaultVersion string) *Versioning {
+	if defaultVersion == "" {
+		panic("defaultVersion should not be empty")
+	}
+
+	r.checkNotFrozen()
+
+	v := &Versioning{
+		router:         r,
+		strategy:       strategy,
+		defaultVersion: defaultVersion,
+		versions:       make(map[string]*APIVersion),
+		routes:         make(map[string]*versionedRoute),
+	}
+
+	r.versionings = append(r.versionings, v)
+
+	return v
+}
+
+// Version returns the [APIVersion] named name, creating it with optional middlewares if it does not exist. The
+// middlewares of a version are applied before the local middlewares of its routes, including the ones registered
+// before the call to Version.
+//
+// If name is empty, a middleware is nil or the router has already started serving requests, Version panics.
+func (v *Versioning) Version(name string, middlewares ...Middleware) *APIVersion {
+	if name == "" {
+		panic("name should not be empty")
+	}
+
+	if slices.ContainsFunc(middlewares, func(m Middleware) bool { return m == nil }) {
+		panic("middlewares should not be nil")
+	}
+
+	v.router.checkNotFrozen()
+
+	version, ok := v.versions[name]
+	if !ok {
+		version = &APIVersion{versioning: v, name: name}
+		v.versions[name] = version
+	}
+
+	version.middlewares = append(version.middlewares, middlewares...)
+
+	return version
+}
+
+// WithDeprecation marks this version as deprecated since date, making its responses carry the Deprecation header
+// (RFC 9745). If sunset is not zero, they also carry the Sunset header (RFC 8594), indicating when the version is
+// expected to become unavailable.
+//
+// If date is zero or the router has already started serving requests, WithDeprecation panics.
+func (v *APIVersion) WithDeprecation(date time.Time, sunset time.Time) *APIVersion {
+	if date.IsZero() {
+		panic("date should not be zero")
+	}
+
+	v.versioning.router.checkNotFrozen()
+
+	v.deprecation = date
+	v.sunset = sunset
+
+	return v
+}
+
+// Handle registers handler for path and method in this version, with optional local middlewares.
+//
+// With [PathVersioning], path is prefixed with the version and the returned [*Route] is exclusive to this version.
+// Otherwise, the returned [*Route] is shared by all versions that register the same method and path, and it's
+// described by the handler and middlewares of the default version (or of the first version that registered it, if
+// the default one has not).
+//
+// If path does not contain a leading slash, method is empty, handler is nil, a middleware is nil, a constraint is
+// unknown or invalid or the router has already started serving requests, Handle panics.
+func (v *APIVersion) Handle(path string, method string, handler Handler, middlewares ...Middleware) *Route {
+	if handler == nil {
+		panic("handler should not be nil")
+	}
+
+	if slices.ContainsFunc(middlewares, func(m Middleware) bool { return m == nil }) {
+		panic("middlewares should not be nil")
+	}
+
+	var (
+		versioning = v.versioning
+		router     = versioning.router
+	)
+
+	if versioning.strategy.prefix != "" {
+		route := v.register(router.handle(nil, versioning.strategy.prefix+v.name+path, method, handler, middlewares))
+
+		if v.name == versioning.defaultVersion {
+			v.register(router.handle(nil, path, method, handler, middlewares))
+		}
+
+		return route
+	}
+
+	router.checkNotFrozen()
+
+	route := v.register(&Route{
+		router:      router,
+		method:      method,
+		pattern:     path,
+		handler:     handler,
+		handlerName: handlerName(handler),
+		middlewares: slices.Clone(middlewares),
+	})
+
+	key := method + " " + path
+
+	if shared, ok := versioning.routes[key]; ok {
+		shared.routes[v.name] = route
+
+		return shared.route
+	}
+
+	shared := &versionedRoute{versioning: versioning, routes: map[string]*Route{v.name: route}, first: v.name}
+	shared.route = router.handle(nil, path, method, versioning.dispatch(shared), nil)
+	shared.route.versioned = shared
+	versioning.routes[key] = shared
+
+	return shared.route
+}
+
+// register makes route belong to this version, so that its responses carry the deprecation headers of the version
+// and its handler is transformed by the middlewares of the version.
+func (v *APIVersion) register(route *Route) *Route {
+	route.version = v
+	route.handler = v.deprecate(route.handler)
+
+	return route
+}
+
+// GET registers handler for path and GET method in this version and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "GET", handler, middlewares)
+func (v *APIVersion) GET(path string, handler Handler, middlewares ...Middleware) *Route {
+	return v.Handle(path, http.MethodGet, handler, middlewares...)
+}
+
+// POST registers handler for path and POST method in this version and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "POST", handler, middlewares)
+func (v *APIVersion) POST(path string, handler Handler, middlewares ...Middleware) *Route {
+	return v.Handle(path, http.MethodPost, handler, middlewares...)
+}
+
+// PUT registers handler for path and PUT method in this version and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "PUT", handler, middlewares)
+func (v *APIVersion) PUT(path string, handler Handler, middlewares ...Middleware) *Route {
+	return v.Handle(path, http.MethodPut, handler, middlewares...)
+}
+
+// PATCH registers handler for path and PATCH method in this version and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "PATCH", handler, middlewares)
+func (v *APIVersion) PATCH(path string, handler Handler, middlewares ...Middleware) *Route {
+	return v.Handle(path, http.MethodPatch, handler, middlewares...)
+}
+
+// DELETE registers handler for path and DELETE method in this version and optional local middlewares.
+//
+// It's equivalent to:
+//
+//	Handle(path, "DELETE", handler, middlewares)
+func (v *APIVersion) DELETE(path string, handler Handler, middlewares ...Middleware) *Route {
+	return v.Handle(path, http.MethodDelete, handler, middlewares...)
+}
+
+func (v *Versioning) dispatch(route *versionedRoute) Handler {
+	return func(r *Request) Response {
+		version := v.strategy.version(r)
+		if version == "" {
+			version = v.defaultVersion
+		}
+
+		if _, ok := v.versions[version]; !ok {
+			return v.unknownVersion(version)
+		}
+
+		handler := v.router.notFound
+		if versioned, ok := route.routes[version]; ok {
+			handler = versioned.chain
+		}
+
+		response := handler(r)
+
+		return ResponseFunc(func(w http.ResponseWriter) {
+			w.Header().Add("Vary", v.strategy.vary)
+
+			if response != nil {
+				response.Write(w)
+			}
+		})
+	}
+}
+
+// freeze applies the middlewares of the versions to the routes of the shared routes.
+func (v *Versioning) freeze() {
+	for _, shared := range v.routes {
+		for _, route := range shared.routes {
+			route.chain = transform(route.handler, route.localMiddlewares())
+		}
+	}
+}
+
+// described returns the route of the version that describes r.
+func (r *versionedRoute) described() *Route {
+	if route, ok := r.routes[r.versioning.defaultVersion]; ok {
+		return route
+	}
+
+	return r.routes[r.first]
+}
+
+func (v *Versioning) unknownVersion(version string) Response {
+	return ResponseFunc(func(w http.ResponseWriter) {
+		w.Header().Add("Vary", v.strategy.vary)
+		http.Error(w, "unknown API version: "+strconv.Quote(version), v.strategy.unknownStatus)
+	})
+}
+
+func (v *APIVersion) deprecate(handler Handler) Handler {
+	return func(r *Request) Response {
+		response := handler(r)
+
+		if v.deprecation.IsZero() || response == nil {
+			return response
+		}
+
+		return ResponseFunc(func(w http.ResponseWriter) {
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(v.deprecation.Unix(), 10))
+
+			if !v.sunset.IsZero() {
+				w.Header().Set("Sunset", v.sunset.UTC().Format(http.TimeFormat))
+			}
+
+			response.Write(w)
+		})
+	}
+}
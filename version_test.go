@@ -0,0 +1,342 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jvcoutinho/lit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_Versioning(t *testing.T) {
+	t.Parallel()
+
+	handler := func(r *lit.Request) lit.Response { return nil }
+
+	tests := []struct {
+		description string
+		register    func(v *lit.Versioning)
+		panicValue  string
+	}{
+		{
+			description: "WhenVersionNameIsEmpty_ShouldPanic",
+			register: func(v *lit.Versioning) {
+				v.Version("")
+			},
+			panicValue: "name should not be empty",
+		},
+		{
+			description: "WhenVersionMiddlewaresContainsANilElement_ShouldPanic",
+			register: func(v *lit.Versioning) {
+				v.Version("1", nil)
+			},
+			panicValue: "middlewares should not be nil",
+		},
+		{
+			description: "WhenDeprecationDateIsZero_ShouldPanic",
+			register: func(v *lit.Versioning) {
+				v.Version("1").WithDeprecation(time.Time{}, time.Time{})
+			},
+			panicValue: "date should not be zero",
+		},
+		{
+			description: "WhenHandlerIsNil_ShouldPanic",
+			register: func(v *lit.Versioning) {
+				v.Version("1").GET("/users", nil)
+			},
+			panicValue: "handler should not be nil",
+		},
+		{
+			description: "WhenSamePathIsRegisteredInSeveralVersions_ShouldNotPanic",
+			register: func(v *lit.Versioning) {
+				v.Version("1").GET("/users", handler)
+				v.Version("2").GET("/users", handler)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			versioning := lit.NewRouter().Versioning(lit.HeaderVersioning("API-Version"), "1")
+
+			// Act
+			// Assert
+			if test.panicValue != "" {
+				require.PanicsWithValue(t, test.panicValue, func() {
+					test.register(versioning)
+				})
+
+				return
+			}
+
+			require.NotPanics(t, func() {
+				test.register(versioning)
+			})
+		})
+	}
+}
+
+func TestRouter_ServeHTTP_Versioning(t *testing.T) {
+	t.Parallel()
+
+	var (
+		deprecation = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		sunset      = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	respond := func(body string) lit.Handler {
+		return func(r *lit.Request) lit.Response {
+			return lit.ResponseFunc(func(w http.ResponseWriter) {
+				w.Write([]byte(body))
+			})
+		}
+	}
+
+	tests := []struct {
+		description         string
+		strategy            lit.VersionStrategy
+		path                string
+		header              http.Header
+		expectedStatusCode  int
+		expectedBody        string
+		expectedDeprecation string
+		expectedSunset      string
+		expectedVary        string
+	}{
+		{
+			description:         "GivenHeaderStrategy_WhenVersionIsNotSpecified_ShouldUseDefaultVersion",
+			strategy:            lit.HeaderVersioning("API-Version"),
+			path:                "/users",
+			expectedStatusCode:  http.StatusOK,
+			expectedBody:        "v1 users",
+			expectedDeprecation: "@1704067200",
+			expectedSunset:      "Wed, 01 Jan 2025 00:00:00 GMT",
+			expectedVary:        "Api-Version",
+		},
+		{
+			description:        "GivenHeaderStrategy_WhenVersionIsKnown_ShouldUseIt",
+			strategy:           lit.HeaderVersioning("API-Version"),
+			path:               "/users",
+			header:             http.Header{"Api-Version": {"2"}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "v2 users",
+			expectedVary:       "Api-Version",
+		},
+		{
+			description:        "GivenHeaderStrategy_WhenVersionIsUnknown_ShouldRespondBadRequest",
+			strategy:           lit.HeaderVersioning("API-Version"),
+			path:               "/users",
+			header:             http.Header{"Api-Version": {"3"}},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "unknown API version: \"3\"\n",
+			expectedVary:       "Api-Version",
+		},
+		{
+			description:        "GivenHeaderStrategy_WhenVersionDoesNotHaveTheRoute_ShouldRespondNotFound",
+			strategy:           lit.HeaderVersioning("API-Version"),
+			path:               "/books",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+			expectedVary:       "Api-Version",
+		},
+		{
+			description:         "GivenMediaTypeStrategy_WhenAcceptIsNotVendorSpecific_ShouldUseDefaultVersion",
+			strategy:            lit.MediaTypeVersioning("acme"),
+			path:                "/users",
+			header:              http.Header{"Accept": {"application/json"}},
+			expectedStatusCode:  http.StatusOK,
+			expectedBody:        "v1 users",
+			expectedDeprecation: "@1704067200",
+			expectedSunset:      "Wed, 01 Jan 2025 00:00:00 GMT",
+			expectedVary:        "Accept",
+		},
+		{
+			description:        "GivenMediaTypeStrategy_WhenVersionIsKnown_ShouldUseIt",
+			strategy:           lit.MediaTypeVersioning("acme"),
+			path:               "/books",
+			header:             http.Header{"Accept": {"text/html, application/vnd.acme.v2+json; q=0.9"}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "v2 books",
+			expectedVary:       "Accept",
+		},
+		{
+			description:        "GivenMediaTypeStrategy_WhenVersionIsUnknown_ShouldRespondNotAcceptable",
+			strategy:           lit.MediaTypeVersioning("acme"),
+			path:               "/users",
+			header:             http.Header{"Accept": {"application/vnd.acme.v3+json"}},
+			expectedStatusCode: http.StatusNotAcceptable,
+			expectedBody:       "unknown API version: \"3\"\n",
+			expectedVary:       "Accept",
+		},
+		{
+			description:        "GivenPathStrategy_WhenPathHasVersionPrefix_ShouldUseIt",
+			strategy:           lit.PathVersioning(),
+			path:               "/v2/users",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "v2 users",
+		},
+		{
+			description:         "GivenPathStrategy_WhenPathHasNoVersionPrefix_ShouldUseDefaultVersion",
+			strategy:            lit.PathVersioning(),
+			path:                "/users",
+			expectedStatusCode:  http.StatusOK,
+			expectedBody:        "v1 users",
+			expectedDeprecation: "@1704067200",
+			expectedSunset:      "Wed, 01 Jan 2025 00:00:00 GMT",
+		},
+		{
+			description:        "GivenPathStrategy_WhenVersionIsUnknown_ShouldRespondNotFound",
+			strategy:           lit.PathVersioning(),
+			path:               "/v3/users",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "404 page not found\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				router     = lit.NewRouter()
+				versioning = router.Versioning(test.strategy, "1")
+				v1         = versioning.Version("1").WithDeprecation(deprecation, sunset)
+				v2         = versioning.Version("2")
+				request    = httptest.NewRequest(http.MethodGet, test.path, nil)
+				recorder   = httptest.NewRecorder()
+			)
+
+			v1.GET("/users", respond("v1 users"))
+			v2.GET("/users", respond("v2 users"))
+			v2.GET("/books", respond("v2 books"))
+
+			for key, values := range test.header {
+				request.Header[key] = values
+			}
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedBody, recorder.Body.String())
+			require.Equal(t, test.expectedDeprecation, recorder.Header().Get("Deprecation"))
+			require.Equal(t, test.expectedSunset, recorder.Header().Get("Sunset"))
+			require.Equal(t, test.expectedVary, recorder.Header().Get("Vary"))
+		})
+	}
+}
+
+func listUsersV1(r *lit.Request) lit.Response {
+	return lit.ResponseFunc(func(w http.ResponseWriter) {
+		w.Write([]byte("v1 users"))
+	})
+}
+
+func listUsersV2(r *lit.Request) lit.Response {
+	return lit.ResponseFunc(func(w http.ResponseWriter) {
+		w.Write([]byte("v2 users"))
+	})
+}
+
+func TestRouter_Versioning_WhenVersionMiddlewaresAreAddedAfterRoutes_ShouldApplyThem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description    string
+		strategy       lit.VersionStrategy
+		path           string
+		header         http.Header
+		expectedRoutes []lit.RouteInfo
+	}{
+		{
+			description: "HeaderStrategy",
+			strategy:    lit.HeaderVersioning("API-Version"),
+			path:        "/users",
+			header:      http.Header{"Api-Version": {"2"}},
+			expectedRoutes: []lit.RouteInfo{
+				{
+					Method:           http.MethodGet,
+					Pattern:          "/users",
+					LocalMiddlewares: 1,
+					Handler:          "github.com/jvcoutinho/lit_test.listUsersV1",
+				},
+			},
+		},
+		{
+			description: "PathStrategy",
+			strategy:    lit.PathVersioning(),
+			path:        "/v2/users",
+			expectedRoutes: []lit.RouteInfo{
+				{
+					Method:           http.MethodGet,
+					Pattern:          "/v1/users",
+					LocalMiddlewares: 1,
+					Handler:          "github.com/jvcoutinho/lit_test.listUsersV1",
+				},
+				{
+					Method:           http.MethodGet,
+					Pattern:          "/users",
+					LocalMiddlewares: 1,
+					Handler:          "github.com/jvcoutinho/lit_test.listUsersV1",
+				},
+				{
+					Method:           http.MethodGet,
+					Pattern:          "/v2/users",
+					LocalMiddlewares: 2,
+					Handler:          "github.com/jvcoutinho/lit_test.listUsersV2",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var (
+				router     = lit.NewRouter()
+				versioning = router.Versioning(test.strategy, "1")
+				request    = httptest.NewRequest(http.MethodGet, test.path, nil)
+				recorder   = httptest.NewRecorder()
+			)
+
+			setHeader := func(value string) lit.Middleware {
+				return func(h lit.Handler) lit.Handler {
+					return func(r *lit.Request) lit.Response {
+						return lit.ResponseFunc(func(w http.ResponseWriter) {
+							w.Header().Add("X-Version-Middleware", value)
+							h(r).Write(w)
+						})
+					}
+				}
+			}
+
+			versioning.Version("1").GET("/users", listUsersV1, setHeader("local"))
+			versioning.Version("2").GET("/users", listUsersV2, setHeader("local"))
+			versioning.Version("2", setHeader("version"))
+
+			for key, values := range test.header {
+				request.Header[key] = values
+			}
+
+			// Act
+			router.ServeHTTP(recorder, request)
+
+			// Assert
+			require.Equal(t, "v2 users", recorder.Body.String())
+			require.Equal(t, []string{"version", "local"}, recorder.Header().Values("X-Version-Middleware"))
+			require.Equal(t, test.expectedRoutes, router.Routes())
+		})
+	}
+}